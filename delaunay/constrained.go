@@ -0,0 +1,124 @@
+package delaunay
+
+import (
+	"fmt"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Shape describes a polygonal region to be triangulated by Constrained.
+//
+// By default, a point is considered to be inside the region if it is
+// enclosed by an odd number of the given rings, and so the distinction
+// between boundaries and holes is only for the convenience of the caller.
+// The rings may then be given in either facing.
+type Shape struct {
+	// Boundaries are the outer rings of the region.
+	Boundaries []geom.Poly
+
+	// Holes are rings enclosing areas within the boundaries that are to be
+	// excluded from the region.
+	Holes []geom.Poly
+
+	// Steiner are additional points that must appear as vertices in the
+	// result. Any that lie outside of the region are ignored.
+	Steiner []geom.Point
+
+	// NonZero selects the non-zero winding rule instead, where a point is
+	// inside the region if the rings wind around it a non-zero number of
+	// times, counting anti-clockwise rings as positive and clockwise rings
+	// as negative. The facing of each ring is then significant, and holes
+	// must face the opposite way to the boundaries that surround them.
+	NonZero bool
+}
+
+// Refinement describes quality requirements for the triangles produced by
+// Constrained, which it meets by inserting additional vertices using
+// Ruppert's algorithm.
+type Refinement struct {
+	// MinAngle is the smallest permitted angle, in degrees, for any corner
+	// of a triangle. Angles formed where two edges of the input meet can't
+	// be improved by refinement and so are exempt.
+	//
+	// Values up to about 20 degrees are guaranteed to terminate, while
+	// larger values often work in practice but may need MaxPoints to be set
+	// to ensure termination. Zero disables the angle requirement.
+	MinAngle float64
+
+	// MaxArea is the largest permitted area for any triangle. Zero disables
+	// the area requirement.
+	MaxArea float64
+
+	// MaxPoints, if non-zero, limits the number of additional vertices that
+	// refinement may insert. When the limit is reached the result may not
+	// meet the other requirements.
+	MaxPoints int
+}
+
+// Constrained produces a constrained Delaunay triangulation of the given
+// shape, whose triangles cover the region enclosed by the shape and whose
+// edges include every edge of the shape's rings.
+//
+// If refine is non-nil then the triangulation is refined by adding new
+// vertices until all triangles meet its requirements.
+//
+// An error is returned if any ring has fewer than three vertices, if any
+// coordinate is invalid as described for Triangulate, or if the rings
+// intersect one another in a way that can't be resolved.
+func Constrained(shape Shape, refine *Refinement) (*Mesh, error) {
+	rings := make([]geom.Poly, 0, len(shape.Boundaries)+len(shape.Holes))
+	rings = append(rings, shape.Boundaries...)
+	rings = append(rings, shape.Holes...)
+
+	var pts []geom.Point
+	for i, ring := range rings {
+		if len(ring) < 3 {
+			return nil, fmt.Errorf("ring %d has only %d vertices", i, len(ring))
+		}
+		for j, p := range ring {
+			if !validPoint(p) {
+				return nil, fmt.Errorf("ring %d vertex %d: %s", i, j, errInvalidCoord)
+			}
+		}
+		pts = append(pts, ring...)
+	}
+	for i, p := range shape.Steiner {
+		if !validPoint(p) {
+			return nil, fmt.Errorf("Steiner point %d: %s", i, errInvalidCoord)
+		}
+	}
+	pts = append(pts, shape.Steiner...)
+
+	tr := newTriangulation(pts, len(pts))
+	vs := make([]int, len(pts))
+	for i, p := range pts {
+		vs[i] = tr.addPoint(p)
+	}
+	tr.inputs = len(tr.pts)
+
+	next := 0
+	for i, ring := range rings {
+		rvs := vs[next : next+len(ring)]
+		next += len(ring)
+		for j := range rvs {
+			a, b := rvs[j], rvs[(j+1)%len(rvs)]
+			if err := tr.addConstraint(a, b, 1); err != nil {
+				return nil, fmt.Errorf("ring %d edge %d: %s", i, j, err)
+			}
+		}
+	}
+
+	tr.carve(func(winding int) bool {
+		if shape.NonZero {
+			return winding != 0
+		}
+		return winding&1 != 0
+	})
+
+	if refine != nil {
+		tr.refine(refine)
+	}
+
+	ret, _ := tr.mesh()
+	return ret, nil
+}
@@ -0,0 +1,269 @@
+package delaunay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+func TestConstrained(t *testing.T) {
+	square := geom.Poly{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	hole := geom.Poly{{3, 3}, {3, 7}, {7, 7}, {7, 3}}
+	notch := geom.Poly{{0, 0}, {10, 0}, {10, 10}, {5, 1}, {0, 10}}
+
+	tests := []struct {
+		Name      string
+		Shape     Shape
+		Refine    *Refinement
+		WantArea  float64
+		WantTris  int // zero means don't check
+		WantEdges [][2]geom.Point
+	}{
+		{
+			Name:     "square",
+			Shape:    Shape{Boundaries: []geom.Poly{square}},
+			WantArea: 100,
+			WantTris: 2,
+		},
+		{
+			Name: "square with hole",
+			Shape: Shape{
+				Boundaries: []geom.Poly{square},
+				Holes:      []geom.Poly{hole},
+			},
+			WantArea: 84,
+			WantTris: 8,
+		},
+		{
+			Name:     "concave",
+			Shape:    Shape{Boundaries: []geom.Poly{notch}},
+			WantArea: 55,
+			WantTris: 3,
+			WantEdges: [][2]geom.Point{
+				{{10, 10}, {5, 1}},
+				{{5, 1}, {0, 10}},
+			},
+		},
+		{
+			Name: "steiner point",
+			Shape: Shape{
+				Boundaries: []geom.Poly{square},
+				Steiner:    []geom.Point{{5, 5}, {20, 20}},
+			},
+			WantArea: 100,
+			WantTris: 4,
+		},
+		{
+			Name: "refined",
+			Shape: Shape{
+				Boundaries: []geom.Poly{notch},
+			},
+			Refine: &Refinement{
+				MinAngle: 20,
+				MaxArea:  2,
+			},
+			WantArea: 55,
+		},
+		{
+			Name: "refined with hole",
+			Shape: Shape{
+				Boundaries: []geom.Poly{square},
+				Holes:      []geom.Poly{hole},
+			},
+			Refine: &Refinement{
+				MinAngle: 25,
+			},
+			WantArea: 84,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := Constrained(test.Shape, test.Refine)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if test.WantTris != 0 && len(got.Triangles) != test.WantTris {
+				t.Errorf("wrong number of triangles %d; want %d", len(got.Triangles), test.WantTris)
+			}
+
+			var area float64
+			for i, tri := range got.Tris() {
+				a := signedArea(tri)
+				if a <= 0 {
+					t.Errorf("triangle %d %#v is not anti-clockwise", i, tri)
+				}
+				area += a
+
+				if test.Refine == nil {
+					continue
+				}
+				if max := test.Refine.MaxArea; max > 0 && a > max {
+					t.Errorf("triangle %d %#v has area %f, exceeding %f", i, tri, a, max)
+				}
+				if min := test.Refine.MinAngle; min > 0 {
+					if got := minAngle(tri); got < min-1e-9 {
+						t.Errorf("triangle %d %#v has angle %f, smaller than %f", i, tri, got, min)
+					}
+				}
+			}
+			if math.Abs(area-test.WantArea) > 1e-9 {
+				t.Errorf("wrong total area %f; want %f", area, test.WantArea)
+			}
+
+			for _, want := range test.WantEdges {
+				if !hasEdge(got, want[0], want[1]) {
+					t.Errorf("missing edge from %#v to %#v", want[0], want[1])
+				}
+			}
+		})
+	}
+}
+
+func TestConstrainedCrossing(t *testing.T) {
+	// Two overlapping squares, whose edges cross. The region enclosed by
+	// exactly one of them is triangulated.
+	got, err := Constrained(Shape{
+		Boundaries: []geom.Poly{
+			{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+			{{2, 2}, {6, 2}, {6, 6}, {2, 6}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var area float64
+	for _, tri := range got.Tris() {
+		area += signedArea(tri)
+	}
+	if want := 24.0; math.Abs(area-want) > 1e-9 {
+		t.Errorf("wrong total area %f; want %f", area, want)
+	}
+	for _, want := range []geom.Point{{4, 2}, {2, 4}} {
+		found := false
+		for _, p := range got.Points {
+			if p == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no vertex at intersection point %#v", want)
+		}
+	}
+}
+
+func TestConstrainedNonZero(t *testing.T) {
+	outer := geom.Poly{{0, 0}, {4, 0}, {4, 4}, {0, 4}}
+	inner := geom.Poly{{1, 1}, {3, 1}, {3, 3}, {1, 3}}
+	innerCW := geom.Poly{{1, 1}, {1, 3}, {3, 3}, {3, 1}}
+
+	tests := []struct {
+		Name       string
+		Boundaries []geom.Poly
+		NonZero    bool
+		WantArea   float64
+	}{
+		{
+			// Two overlapping squares facing the same way, whose overlap
+			// is enclosed twice in the same direction.
+			"overlapping even-odd",
+			[]geom.Poly{outer, {{2, 2}, {6, 2}, {6, 6}, {2, 6}}},
+			false,
+			24,
+		},
+		{
+			"overlapping non-zero",
+			[]geom.Poly{outer, {{2, 2}, {6, 2}, {6, 6}, {2, 6}}},
+			true,
+			28,
+		},
+		{
+			// An inner ring facing the same way as the outer one fills
+			// in, rather than cutting a hole.
+			"same facing non-zero",
+			[]geom.Poly{outer, inner},
+			true,
+			16,
+		},
+		{
+			"same facing even-odd",
+			[]geom.Poly{outer, inner},
+			false,
+			12,
+		},
+		{
+			"opposite facing non-zero",
+			[]geom.Poly{outer, innerCW},
+			true,
+			12,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := Constrained(Shape{
+				Boundaries: test.Boundaries,
+				NonZero:    test.NonZero,
+			}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var area float64
+			for _, tri := range got.Tris() {
+				area += signedArea(tri)
+			}
+			if math.Abs(area-test.WantArea) > 1e-9 {
+				t.Errorf("wrong total area %f; want %f", area, test.WantArea)
+			}
+		})
+	}
+}
+
+func TestConstrainedInvalid(t *testing.T) {
+	_, err := Constrained(Shape{
+		Boundaries: []geom.Poly{{{0, 0}, {1, 1}}},
+	}, nil)
+	if err == nil {
+		t.Fatalf("succeeded; want error")
+	}
+
+	_, err = Constrained(Shape{
+		Boundaries: []geom.Poly{{{0, 0}, {1, 0}, {0, 1}}},
+		Steiner:    []geom.Point{{math.NaN(), 0}},
+	}, nil)
+	if err == nil {
+		t.Fatalf("succeeded with NaN Steiner point; want error")
+	}
+}
+
+func signedArea(t geom.Tri) float64 {
+	return orient(t[0], t[1], t[2]) / 2
+}
+
+func minAngle(t geom.Tri) float64 {
+	min := math.Inf(1)
+	for i := range t {
+		a, b := t[(i+1)%3].Sub(t[i]), t[(i+2)%3].Sub(t[i])
+		cos := (a.X*b.X + a.Y*b.Y) / (math.Hypot(a.X, a.Y) * math.Hypot(b.X, b.Y))
+		if deg := math.Acos(cos) * 180 / math.Pi; deg < min {
+			min = deg
+		}
+	}
+	return min
+}
+
+func hasEdge(m *Mesh, a, b geom.Point) bool {
+	for _, tri := range m.Triangles {
+		for i := range tri {
+			u, v := m.Points[tri[i]], m.Points[tri[(i+1)%3]]
+			if (u == a && v == b) || (u == b && v == a) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,3 @@
+// Package delaunay produces triangulations of sets of points and of polygonal
+// regions described using types from package geom.
+package delaunay
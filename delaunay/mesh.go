@@ -0,0 +1,27 @@
+package delaunay
+
+import (
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Mesh is a set of triangles that share vertices.
+type Mesh struct {
+	// Points are the vertices of the mesh. Each point appears only once,
+	// even if it was given multiple times in the input.
+	Points []geom.Point
+
+	// Triangles are triples of indices into Points. The vertices of each
+	// triangle are in anti-clockwise order, assuming an X axis that increases
+	// to the right and a Y axis that increases upward, and so the Facing of
+	// each triangle is -1.
+	Triangles [][3]int
+}
+
+// Tris returns the triangles of the mesh as a slice of separate triangles.
+func (m *Mesh) Tris() []geom.Tri {
+	ret := make([]geom.Tri, len(m.Triangles))
+	for i, t := range m.Triangles {
+		ret[i] = geom.Tri{m.Points[t[0]], m.Points[t[1]], m.Points[t[2]]}
+	}
+	return ret
+}
@@ -0,0 +1,36 @@
+package delaunay
+
+import (
+	"github.com/apparentlymart/go-geometry/geom"
+	"github.com/apparentlymart/go-geometry/geom/robust"
+)
+
+// orient returns a positive value if the points a, b and c are in
+// anti-clockwise order, a negative value if they are in clockwise order, and
+// zero if they are collinear. The sign of the result is exact; see
+// robust.Orient2D.
+func orient(a, b, c geom.Point) float64 {
+	return robust.Orient2D(a.X, a.Y, b.X, b.Y, c.X, c.Y)
+}
+
+// inCircle returns a positive value if point d lies inside the circle passing
+// through a, b and c, which must be in anti-clockwise order. The result is
+// negative if d is outside that circle and zero if it is on the circle. The
+// sign of the result is exact; see robust.InCircle.
+func inCircle(a, b, c, d geom.Point) float64 {
+	return robust.InCircle(a.X, a.Y, b.X, b.Y, c.X, c.Y, d.X, d.Y)
+}
+
+// circumcenter returns the center of the circle passing through the three
+// given points. The result is not meaningful if the points are collinear.
+func circumcenter(a, b, c geom.Point) geom.Point {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	bl := bx*bx + by*by
+	cl := cx*cx + cy*cy
+	return geom.Point{
+		X: a.X + (cy*bl-by*cl)/d,
+		Y: a.Y + (bx*cl-cx*bl)/d,
+	}
+}
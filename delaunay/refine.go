@@ -0,0 +1,264 @@
+package delaunay
+
+import (
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// refine inserts additional vertices into a carved triangulation until all
+// of its triangles meet the given requirements, using Ruppert's algorithm.
+//
+// Encroached constraint edges are always split first. A bad triangle is then
+// fixed by inserting its circumcenter, unless that point would encroach on a
+// constraint edge, in which case the edge is split instead.
+func (tr *triangulation) refine(r *Refinement) {
+	cosMin := math.Cos(r.MinAngle * math.Pi / 180)
+	if r.MinAngle <= 0 {
+		cosMin = 2 // no angle can have a cosine this large
+	}
+
+	// Triangles whose shortest edge is below this length are never split,
+	// so that numerical noise can't cause refinement to run forever.
+	var floor float64
+	for i, p := range tr.pts[superVertices:] {
+		if i == 0 || math.Abs(p.X) > floor {
+			floor = math.Abs(p.X)
+		}
+		if math.Abs(p.Y) > floor {
+			floor = math.Abs(p.Y)
+		}
+	}
+	floor *= 1e-9
+	floor *= floor
+
+	added := 0
+	budget := func() bool {
+		return r.MaxPoints == 0 || added < r.MaxPoints
+	}
+
+	segQueue := make([]int, len(tr.tris))
+	triQueue := make([]int, len(tr.tris))
+	for t := range tr.tris {
+		segQueue[t] = t
+		triQueue[t] = t
+	}
+	tr.touched = make([]int, 0, 16)
+	flush := func() {
+		segQueue = append(segQueue, tr.touched...)
+		triQueue = append(triQueue, tr.touched...)
+		tr.touched = tr.touched[:0]
+	}
+
+	for budget() {
+		for len(segQueue) > 0 && budget() {
+			t := segQueue[len(segQueue)-1]
+			segQueue = segQueue[:len(segQueue)-1]
+			for i := 0; i < 3; i++ {
+				if tr.tris[t].c[i] && tr.encroached(t, i) && tr.splittable(t, i, floor) {
+					tr.splitSegment(t, i)
+					added++
+					flush()
+					break
+				}
+			}
+		}
+		if !budget() {
+			break
+		}
+
+		found := false
+		for len(triQueue) > 0 {
+			t := triQueue[len(triQueue)-1]
+			triQueue = triQueue[:len(triQueue)-1]
+			if tr.bad(t, r.MaxArea, cosMin, floor) {
+				if tr.fixTriangle(t, floor) {
+					// Splitting an encroached edge may not have changed t,
+					// so we must revisit it in case it's still bad.
+					triQueue = append(triQueue, t)
+					added++
+					found = true
+					flush()
+					break
+				}
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	tr.touched = nil
+}
+
+// bad returns true if triangle t fails to meet the given requirements.
+func (tr *triangulation) bad(t int, maxArea, cosMin, floor float64) bool {
+	tri := &tr.tris[t]
+	var p [3]geom.Point
+	for i, v := range tri.v {
+		p[i] = tr.pts[v]
+	}
+
+	var l [3]float64 // squared length of edge i
+	shortest := 0
+	for i := range l {
+		l[i] = p[(i+2)%3].Sub(p[(i+1)%3]).LenSq()
+		if l[i] < l[shortest] {
+			shortest = i
+		}
+	}
+	if l[shortest] < floor {
+		return false
+	}
+
+	if maxArea > 0 && orient(p[0], p[1], p[2])/2 > maxArea {
+		return true
+	}
+
+	// The smallest angle is opposite the shortest edge.
+	a, b := l[(shortest+1)%3], l[(shortest+2)%3]
+	cos := (a + b - l[shortest]) / (2 * math.Sqrt(a*b))
+	if cos <= cosMin {
+		return false
+	}
+
+	// An angle between two constraint edges is part of the input, and so
+	// no amount of refinement can improve it.
+	return !(tri.c[(shortest+1)%3] && tri.c[(shortest+2)%3])
+}
+
+// encroached returns true if the apex of either triangle adjacent to edge i
+// of triangle t lies inside the edge's diametral circle.
+func (tr *triangulation) encroached(t, i int) bool {
+	tri := &tr.tris[t]
+	u, v := tr.pts[tri.v[(i+1)%3]], tr.pts[tri.v[(i+2)%3]]
+	if inDiametral(u, v, tr.pts[tri.v[i]]) {
+		return true
+	}
+	if s := tri.n[i]; s >= 0 {
+		stri := &tr.tris[s]
+		return inDiametral(u, v, tr.pts[stri.v[stri.neighborIndex(t)]])
+	}
+	return false
+}
+
+func (tr *triangulation) splittable(t, i int, floor float64) bool {
+	tri := &tr.tris[t]
+	return tr.pts[tri.v[(i+2)%3]].Sub(tr.pts[tri.v[(i+1)%3]]).LenSq() >= 4*floor
+}
+
+// splitSegment splits constraint edge i of triangle t.
+//
+// Segments are usually split at their midpoint, but if only one end is a
+// vertex from the input then the split point is instead placed at a
+// power-of-two distance from it. This "concentric shells" approach
+// prevents endless splitting of segments that meet at small input angles,
+// because the resulting subsegments around that vertex all have matching
+// lengths and so can't encroach one another.
+func (tr *triangulation) splitSegment(t, i int) {
+	tri := &tr.tris[t]
+	ui, vi := tri.v[(i+1)%3], tri.v[(i+2)%3]
+	u, v := tr.pts[ui], tr.pts[vi]
+	uIn, vIn := ui < tr.inputs, vi < tr.inputs
+	if uIn == vIn {
+		tr.splitEdge(t, i, u.Add(v).Scale(0.5))
+		return
+	}
+	if vIn {
+		u, v = v, u
+	}
+	d := v.Sub(u)
+	l := d.Len()
+	s := math.Exp2(math.Round(math.Log2(l / 2)))
+	// Keep the split point away from both ends, in case rounding put the
+	// nearest power of two too close to either.
+	if s < l/4 || s > l*3/4 {
+		s = l / 2
+	}
+	tr.splitEdge(t, i, u.Add(d.Scale(s/l)))
+}
+
+// fixTriangle attempts to improve bad triangle t by inserting its
+// circumcenter, or by splitting a constraint edge that the circumcenter
+// would encroach upon. The result is false if no change was made.
+func (tr *triangulation) fixTriangle(t int, floor float64) bool {
+	tri := &tr.tris[t]
+	c := circumcenter(tr.pts[tri.v[0]], tr.pts[tri.v[1]], tr.pts[tri.v[2]])
+	if math.IsNaN(c.X) || math.IsInf(c.X, 0) || math.IsNaN(c.Y) || math.IsInf(c.Y, 0) {
+		return false
+	}
+
+	// Walk from the bad triangle towards its circumcenter. If we must cross
+	// a constraint edge to get there then the circumcenter isn't visible
+	// from the triangle, and so we split that edge instead.
+	cur := t
+	for step := 0; ; step++ {
+		if step > len(tr.tris) {
+			return false
+		}
+		ctri := &tr.tris[cur]
+		next := -1
+		for k := 0; k < 3; k++ {
+			i := (k + step) % 3
+			a, b := tr.pts[ctri.v[(i+1)%3]], tr.pts[ctri.v[(i+2)%3]]
+			if orient(a, b, c) >= 0 {
+				continue
+			}
+			if ctri.c[i] || ctri.n[i] < 0 {
+				if !tr.splittable(cur, i, floor) {
+					return false
+				}
+				tr.splitSegment(cur, i)
+				return true
+			}
+			next = ctri.n[i]
+			break
+		}
+		if next < 0 {
+			break
+		}
+		cur = next
+	}
+
+	// The circumcenter must also not encroach on any constraint edge near
+	// where it would be inserted.
+	check := [4]int{cur, tr.tris[cur].n[0], tr.tris[cur].n[1], tr.tris[cur].n[2]}
+	for _, ct := range check {
+		if ct < 0 {
+			continue
+		}
+		ctri := &tr.tris[ct]
+		for i := 0; i < 3; i++ {
+			if !ctri.c[i] {
+				continue
+			}
+			u, v := tr.pts[ctri.v[(i+1)%3]], tr.pts[ctri.v[(i+2)%3]]
+			if inDiametral(u, v, c) {
+				if !tr.splittable(ct, i, floor) {
+					return false
+				}
+				tr.splitSegment(ct, i)
+				return true
+			}
+		}
+	}
+
+	switch loc, i := tr.classify(cur, c); loc {
+	case locInside:
+		tr.splitTriangle(cur, c)
+	case locEdge:
+		if tr.tris[cur].c[i] {
+			tr.splitSegment(cur, i)
+		} else {
+			tr.splitEdge(cur, i, c)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// inDiametral returns true if p lies strictly inside the circle whose
+// diameter is the segment from u to v.
+func inDiametral(u, v, p geom.Point) bool {
+	return u.Sub(p).Dot(v.Sub(p)) < 0
+}
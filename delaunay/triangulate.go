@@ -0,0 +1,79 @@
+package delaunay
+
+import (
+	"fmt"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Triangulate produces the Delaunay triangulation of the given points, whose
+// triangles exactly cover the convex hull of the points and whose
+// circumcircles contain none of the other points.
+//
+// Points that appear more than once in the input appear only once in the
+// result, but the result otherwise has its points in the same order as the
+// input. If all of the points are collinear then the result has no
+// triangles.
+//
+// An error is returned if any coordinate is not finite or is too large in
+// magnitude to triangulate exactly, which is the case beyond 1e50.
+func Triangulate(pts []geom.Point) (*Mesh, error) {
+	ret, _, err := triangulatePoints(pts)
+	return ret, err
+}
+
+// triangulatePoints is the main implementation of Triangulate, which also
+// returns the index in the result of each of the given points.
+func triangulatePoints(pts []geom.Point) (*Mesh, []int, error) {
+	for i, p := range pts {
+		if !validPoint(p) {
+			return nil, nil, fmt.Errorf("point %d: %s", i, errInvalidCoord)
+		}
+	}
+
+	tr := newTriangulation(pts, len(pts))
+	vs := make([]int, len(pts))
+	for i, p := range pts {
+		vs[i] = tr.addPoint(p)
+	}
+
+	hull := geom.ConvexHull(pts)
+	if len(hull) < 3 {
+		// All of the points are collinear, so there are no triangles.
+		ret := &Mesh{
+			Points: tr.pts[superVertices:],
+		}
+		for i := range vs {
+			vs[i] -= superVertices
+		}
+		return ret, vs, nil
+	}
+
+	// The edges of the convex hull are always Delaunay edges, so adding them
+	// as constraints changes nothing inside the hull but lets us remove the
+	// triangles outside it, including any that the super triangle might
+	// have distorted.
+	hullVs := make(map[geom.Point]int, len(hull))
+	for i, p := range pts {
+		hullVs[p] = vs[i]
+	}
+
+	// The hull edges are the only constraints and meet one another only at
+	// their ends, so adding them shouldn't fail, but we report it rather
+	// than returning a triangulation with triangles outside the hull.
+	for i := range hull {
+		a, b := hullVs[hull[i]], hullVs[hull[(i+1)%len(hull)]]
+		if err := tr.addConstraint(a, b, 1); err != nil {
+			return nil, nil, fmt.Errorf("convex hull edge %d: %s", i, err)
+		}
+	}
+	tr.carve(func(winding int) bool {
+		return winding != 0
+	})
+
+	ret, index := tr.mesh()
+	for i, v := range vs {
+		vs[i] = index[v]
+	}
+	return ret, vs, nil
+}
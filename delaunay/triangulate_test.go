@@ -0,0 +1,114 @@
+package delaunay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+func TestTriangulate(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Points     []geom.Point
+		WantPoints int
+		WantTris   int
+		WantArea   float64
+	}{
+		{
+			Name:       "empty",
+			Points:     nil,
+			WantPoints: 0,
+		},
+		{
+			Name:       "collinear",
+			Points:     []geom.Point{{0, 0}, {1, 1}, {2, 2}},
+			WantPoints: 3,
+		},
+		{
+			Name:       "triangle",
+			Points:     []geom.Point{{0, 0}, {1, 0}, {0, 1}},
+			WantPoints: 3,
+			WantTris:   1,
+			WantArea:   0.5,
+		},
+		{
+			Name:       "duplicates",
+			Points:     []geom.Point{{0, 0}, {1, 0}, {0, 0}, {0, 1}, {1, 0}},
+			WantPoints: 3,
+			WantTris:   1,
+			WantArea:   0.5,
+		},
+		{
+			// A regular grid has many cocircular points and several
+			// collinear points on the hull.
+			Name:       "grid",
+			Points:     grid(5, 4),
+			WantPoints: 20,
+			WantTris:   24,
+			WantArea:   12,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := Triangulate(test.Points)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got.Points) != test.WantPoints {
+				t.Errorf("wrong number of points %d; want %d", len(got.Points), test.WantPoints)
+			}
+			if len(got.Triangles) != test.WantTris {
+				t.Errorf("wrong number of triangles %d; want %d", len(got.Triangles), test.WantTris)
+			}
+
+			var area float64
+			for _, tri := range got.Tris() {
+				area += signedArea(tri)
+			}
+			if math.Abs(area-test.WantArea) > 1e-9 {
+				t.Errorf("wrong total area %f; want %f", area, test.WantArea)
+			}
+
+			for i, tri := range got.Tris() {
+				for _, p := range got.Points {
+					if inCircle(tri[0], tri[1], tri[2], p) > 1e-9 {
+						t.Errorf("triangle %d %#v circumcircle contains %#v", i, tri, p)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTriangulateInvalid(t *testing.T) {
+	tests := map[string]geom.Point{
+		"NaN":       {math.NaN(), 0},
+		"infinity":  {0, math.Inf(-1)},
+		"too large": {1e300, 0},
+	}
+
+	for name, p := range tests {
+		t.Run(name, func(t *testing.T) {
+			pts := []geom.Point{{0, 0}, {1, 0}, {0, 1}, p}
+			if _, err := Triangulate(pts); err == nil {
+				t.Errorf("Triangulate succeeded; want error")
+			}
+			if _, err := Voronoi(pts, geom.Rect{{-1, -1}, {1, 1}}); err == nil {
+				t.Errorf("Voronoi succeeded; want error")
+			}
+		})
+	}
+}
+
+func grid(w, h int) []geom.Point {
+	ret := make([]geom.Point, 0, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			ret = append(ret, geom.Point{X: float64(x), Y: float64(y)})
+		}
+	}
+	return ret
+}
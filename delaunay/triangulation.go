@@ -0,0 +1,804 @@
+package delaunay
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// triangle is a single triangle within a triangulation.
+//
+// The vertices are always stored in anti-clockwise order, assuming an X axis
+// that increases to the right and a Y axis that increases upward. Edge i of
+// a triangle is the edge opposite vertex i, running from v[(i+1)%3] to
+// v[(i+2)%3].
+type triangle struct {
+	// v are the indices of the vertices of the triangle.
+	v [3]int
+
+	// n are the indices of the neighboring triangles, where n[i] shares
+	// edge i. An index of -1 means that there is no neighbor across that edge.
+	n [3]int
+
+	// c records whether each edge is a constraint that must be preserved.
+	c [3]bool
+
+	// w is the winding number of this triangle minus that of the neighbor
+	// across each edge, and so the winding number decreases by w[i] when
+	// crossing edge i out of this triangle. It is the total weight of the
+	// constraints running along the edge in the same direction as the
+	// triangle's anti-clockwise vertex order, minus those running in the
+	// opposite direction.
+	w [3]int
+}
+
+// triangulation is the mutable triangle mesh that all of the exported
+// functions in this package are built on.
+//
+// The first three points are the vertices of a "super triangle" that encloses
+// all of the other points, so that every insertion happens inside an
+// existing triangle.
+type triangulation struct {
+	pts  []geom.Point
+	tris []triangle
+
+	// vt records a triangle that each vertex belongs to, or -1 for vertices
+	// that are not currently part of any triangle.
+	vt []int
+
+	// last is the most recently-created triangle, used as a starting point
+	// when locating the next point to be inserted.
+	last int
+
+	// inputs is the number of vertices, including the super triangle, that
+	// were given as input rather than added by refinement.
+	inputs int
+
+	// touched, if non-nil, collects the indices of all triangles that are
+	// created or modified, so that refinement can revisit them.
+	touched []int
+}
+
+const superVertices = 3
+
+const (
+	locOutside = iota
+	locInside
+	locEdge
+	locVertex
+)
+
+var errCrossingConstraints = errors.New("constraint edges cross at a point that cannot be represented")
+
+// maxCoord is the largest magnitude of coordinate that a triangulation
+// accepts. The super triangle extends well beyond the input points and
+// inCircle multiplies together four differences between coordinates, so
+// larger coordinates could overflow and leave the predicates inexact, after
+// which the triangulation may never settle.
+const maxCoord = 1e50
+
+var errInvalidCoord = fmt.Errorf("coordinates must be finite and no larger in magnitude than %g", maxCoord)
+
+// validPoint returns true if both coordinates of the given point are finite
+// and no larger in magnitude than maxCoord.
+func validPoint(p geom.Point) bool {
+	return math.Abs(p.X) <= maxCoord && math.Abs(p.Y) <= maxCoord
+}
+
+// newTriangulation creates a triangulation containing only a super triangle
+// large enough to contain all of the given points.
+func newTriangulation(pts []geom.Point, capHint int) *triangulation {
+	var min, max geom.Point
+	for i, p := range pts {
+		if i == 0 {
+			min, max = p, p
+			continue
+		}
+		if p.X < min.X {
+			min.X = p.X
+		}
+		if p.Y < min.Y {
+			min.Y = p.Y
+		}
+		if p.X > max.X {
+			max.X = p.X
+		}
+		if p.Y > max.Y {
+			max.Y = p.Y
+		}
+	}
+	c := geom.Point{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2}
+	d := max.X - min.X
+	if h := max.Y - min.Y; h > d {
+		d = h
+	}
+	if d == 0 {
+		d = 1
+	}
+
+	tr := &triangulation{
+		pts:  make([]geom.Point, superVertices, superVertices+capHint),
+		tris: make([]triangle, 1, 2*capHint+1),
+		vt:   make([]int, superVertices, superVertices+capHint),
+	}
+	tr.pts[0] = geom.Point{X: c.X - 20*d, Y: c.Y - 10*d}
+	tr.pts[1] = geom.Point{X: c.X + 20*d, Y: c.Y - 10*d}
+	tr.pts[2] = geom.Point{X: c.X, Y: c.Y + 20*d}
+	tr.tris[0] = triangle{
+		v: [3]int{0, 1, 2},
+		n: [3]int{-1, -1, -1},
+	}
+	return tr
+}
+
+// addPoint inserts the given point into the triangulation, returning the
+// index of the new vertex.
+//
+// If the point coincides with an existing vertex then the index of that
+// vertex is returned instead. If the point lies outside of the
+// triangulation then the result is -1.
+func (tr *triangulation) addPoint(p geom.Point) int {
+	t, loc, i := tr.locate(p, tr.last)
+	switch loc {
+	case locVertex:
+		return tr.tris[t].v[i]
+	case locEdge:
+		return tr.splitEdge(t, i, p)
+	case locInside:
+		return tr.splitTriangle(t, p)
+	default:
+		return -1
+	}
+}
+
+func (tr *triangulation) newVertex(p geom.Point) int {
+	tr.pts = append(tr.pts, p)
+	tr.vt = append(tr.vt, -1)
+	return len(tr.pts) - 1
+}
+
+func (tr *triangulation) newTriangle(t triangle) int {
+	tr.tris = append(tr.tris, t)
+	return len(tr.tris) - 1
+}
+
+func (tr *triangulation) touch(ts ...int) {
+	if tr.touched != nil {
+		tr.touched = append(tr.touched, ts...)
+	}
+}
+
+// locate finds the triangle containing the given point by walking across
+// the mesh from the given starting triangle, falling back on an exhaustive
+// search if the walk fails.
+//
+// The second result describes where the point was found. For locEdge the
+// third result is the index of the edge the point lies on, while for
+// locVertex it is the index of the coincident vertex.
+func (tr *triangulation) locate(p geom.Point, start int) (int, int, int) {
+	if start < 0 || start >= len(tr.tris) {
+		start = 0
+	}
+	t := start
+	for step := 0; step <= len(tr.tris); step++ {
+		tri := &tr.tris[t]
+		next := -1
+		for k := 0; k < 3; k++ {
+			// Varying the starting edge prevents the walk from cycling
+			// forever in meshes that are not Delaunay.
+			i := (k + step) % 3
+			a, b := tr.pts[tri.v[(i+1)%3]], tr.pts[tri.v[(i+2)%3]]
+			if orient(a, b, p) < 0 {
+				next = tri.n[i]
+				if next < 0 {
+					return tr.locateScan(p)
+				}
+				break
+			}
+		}
+		if next < 0 {
+			loc, i := tr.classify(t, p)
+			return t, loc, i
+		}
+		t = next
+	}
+	return tr.locateScan(p)
+}
+
+func (tr *triangulation) locateScan(p geom.Point) (int, int, int) {
+	for t := range tr.tris {
+		if loc, i := tr.classify(t, p); loc != locOutside {
+			return t, loc, i
+		}
+	}
+	return -1, locOutside, -1
+}
+
+func (tr *triangulation) classify(t int, p geom.Point) (int, int) {
+	tri := &tr.tris[t]
+	for i, v := range tri.v {
+		if tr.pts[v] == p {
+			return locVertex, i
+		}
+	}
+	edge := -1
+	for i := 0; i < 3; i++ {
+		a, b := tr.pts[tri.v[(i+1)%3]], tr.pts[tri.v[(i+2)%3]]
+		o := orient(a, b, p)
+		switch {
+		case o < 0:
+			return locOutside, -1
+		case o == 0:
+			if edge >= 0 {
+				// On two edges at once means on their shared vertex.
+				return locVertex, 3 - edge - i
+			}
+			edge = i
+		}
+	}
+	if edge >= 0 {
+		return locEdge, edge
+	}
+	return locInside, -1
+}
+
+// splitTriangle inserts a new vertex at p, which must lie strictly inside
+// triangle t, replacing t with three new triangles.
+func (tr *triangulation) splitTriangle(t int, p geom.Point) int {
+	pi := tr.newVertex(p)
+	old := tr.tris[t]
+	a, b, c := old.v[0], old.v[1], old.v[2]
+	t1 := len(tr.tris)
+	t2 := t1 + 1
+
+	tr.tris[t] = triangle{
+		v: [3]int{pi, b, c},
+		n: [3]int{old.n[0], t1, t2},
+		c: [3]bool{old.c[0], false, false},
+		w: [3]int{old.w[0], 0, 0},
+	}
+	tr.newTriangle(triangle{
+		v: [3]int{a, pi, c},
+		n: [3]int{t, old.n[1], t2},
+		c: [3]bool{false, old.c[1], false},
+		w: [3]int{0, old.w[1], 0},
+	})
+	tr.newTriangle(triangle{
+		v: [3]int{a, b, pi},
+		n: [3]int{t, t1, old.n[2]},
+		c: [3]bool{false, false, old.c[2]},
+		w: [3]int{0, 0, old.w[2]},
+	})
+	tr.replaceNeighbor(old.n[1], t, t1)
+	tr.replaceNeighbor(old.n[2], t, t2)
+	tr.vt[a], tr.vt[b], tr.vt[c], tr.vt[pi] = t1, t, t, t
+	tr.last = t
+	tr.touch(t, t1, t2)
+
+	tr.legalize(t, 0, t1, 1, t2, 2)
+	return pi
+}
+
+// splitEdge inserts a new vertex at p, which must lie on edge i of triangle
+// t, replacing t and its neighbor across that edge with two triangles each.
+//
+// If the edge was a constraint then the two halves are constraints too.
+func (tr *triangulation) splitEdge(t, i int, p geom.Point) int {
+	pi := tr.newVertex(p)
+	old := tr.tris[t]
+	a, u, w := old.v[i], old.v[(i+1)%3], old.v[(i+2)%3]
+	s := old.n[i]
+
+	t2 := tr.newTriangle(triangle{})
+	s2 := -1
+	if s >= 0 {
+		s2 = tr.newTriangle(triangle{})
+	}
+
+	tr.tris[t] = triangle{
+		v: [3]int{a, u, pi},
+		n: [3]int{s2, t2, old.n[(i+2)%3]},
+		c: [3]bool{old.c[i], false, old.c[(i+2)%3]},
+		w: [3]int{old.w[i], 0, old.w[(i+2)%3]},
+	}
+	tr.tris[t2] = triangle{
+		v: [3]int{a, pi, w},
+		n: [3]int{s, old.n[(i+1)%3], t},
+		c: [3]bool{old.c[i], old.c[(i+1)%3], false},
+		w: [3]int{old.w[i], old.w[(i+1)%3], 0},
+	}
+	tr.replaceNeighbor(old.n[(i+1)%3], t, t2)
+	tr.vt[a], tr.vt[u], tr.vt[w], tr.vt[pi] = t, t, t2, t
+	tr.last = t
+	tr.touch(t, t2)
+
+	if s < 0 {
+		tr.legalize(t, 2, t2, 1)
+		return pi
+	}
+
+	olds := tr.tris[s]
+	j := olds.neighborIndex(t)
+	b := olds.v[j]
+	tr.tris[s] = triangle{
+		v: [3]int{b, w, pi},
+		n: [3]int{t2, s2, olds.n[(j+2)%3]},
+		c: [3]bool{olds.c[j], false, olds.c[(j+2)%3]},
+		w: [3]int{olds.w[j], 0, olds.w[(j+2)%3]},
+	}
+	tr.tris[s2] = triangle{
+		v: [3]int{b, pi, u},
+		n: [3]int{t, olds.n[(j+1)%3], s},
+		c: [3]bool{olds.c[j], olds.c[(j+1)%3], false},
+		w: [3]int{olds.w[j], olds.w[(j+1)%3], 0},
+	}
+	tr.replaceNeighbor(olds.n[(j+1)%3], s, s2)
+	tr.vt[b] = s
+	tr.touch(s, s2)
+
+	tr.legalize(t, 2, t2, 1, s, 2, s2, 1)
+	return pi
+}
+
+// legalize restores the Delaunay property after inserting a vertex, given
+// pairs of triangle and edge index for each edge opposite the new vertex.
+func (tr *triangulation) legalize(edges ...int) {
+	stack := edges
+	for len(stack) > 0 {
+		t, i := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+
+		tri := &tr.tris[t]
+		s := tri.n[i]
+		if s < 0 || tri.c[i] {
+			continue
+		}
+		q := tr.tris[s].v[tr.tris[s].neighborIndex(t)]
+		if inCircle(tr.pts[tri.v[0]], tr.pts[tri.v[1]], tr.pts[tri.v[2]], tr.pts[q]) <= 0 {
+			continue
+		}
+		tr.flip(t, i)
+		// After the flip the new vertex is vertex 0 of t and vertex 2 of s.
+		stack = append(stack, t, 0, s, 2)
+	}
+}
+
+// flip replaces edge i of triangle t with the other diagonal of the
+// quadrilateral formed by t and its neighbor across that edge.
+//
+// Afterwards, t has the former apex of t as its vertex 0 and the former
+// apex of the neighbor as its vertex 2, while the neighbor has those same
+// vertices at positions 2 and 0 respectively.
+func (tr *triangulation) flip(t, i int) {
+	ot := tr.tris[t]
+	s := ot.n[i]
+	os := tr.tris[s]
+	j := os.neighborIndex(t)
+
+	p, u, v := ot.v[i], ot.v[(i+1)%3], ot.v[(i+2)%3]
+	q := os.v[j]
+	na, nb := ot.n[(i+1)%3], ot.n[(i+2)%3]
+	nc, nd := os.n[(j+1)%3], os.n[(j+2)%3]
+
+	tr.tris[t] = triangle{
+		v: [3]int{p, u, q},
+		n: [3]int{nc, s, nb},
+		c: [3]bool{os.c[(j+1)%3], false, ot.c[(i+2)%3]},
+		w: [3]int{os.w[(j+1)%3], 0, ot.w[(i+2)%3]},
+	}
+	tr.tris[s] = triangle{
+		v: [3]int{q, v, p},
+		n: [3]int{na, t, nd},
+		c: [3]bool{ot.c[(i+1)%3], false, os.c[(j+2)%3]},
+		w: [3]int{ot.w[(i+1)%3], 0, os.w[(j+2)%3]},
+	}
+	tr.replaceNeighbor(na, t, s)
+	tr.replaceNeighbor(nc, s, t)
+	tr.vt[p], tr.vt[u], tr.vt[v], tr.vt[q] = t, t, s, s
+	tr.touch(t, s)
+}
+
+func (tr *triangulation) replaceNeighbor(t, old, new int) {
+	if t < 0 {
+		return
+	}
+	tri := &tr.tris[t]
+	for i := range tri.n {
+		if tri.n[i] == old {
+			tri.n[i] = new
+			return
+		}
+	}
+}
+
+func (t *triangle) neighborIndex(n int) int {
+	for i := range t.n {
+		if t.n[i] == n {
+			return i
+		}
+	}
+	panic("triangles are not neighbors")
+}
+
+func (t *triangle) vertexIndex(v int) int {
+	for i := range t.v {
+		if t.v[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// around returns all of the triangles that have the given vertex as one of
+// their corners.
+func (tr *triangulation) around(v int) []int {
+	start := tr.vt[v]
+	if start < 0 {
+		return nil
+	}
+	var ret []int
+	t := start
+	for {
+		ret = append(ret, t)
+		tri := &tr.tris[t]
+		t = tri.n[(tri.vertexIndex(v)+1)%3]
+		if t == start {
+			return ret
+		}
+		if t < 0 {
+			break
+		}
+	}
+
+	// If we get here then the vertex is on the boundary of the mesh, and so
+	// we must also walk around in the other direction.
+	t = start
+	for {
+		tri := &tr.tris[t]
+		t = tri.n[(tri.vertexIndex(v)+2)%3]
+		if t < 0 {
+			return ret
+		}
+		ret = append(ret, t)
+	}
+}
+
+// findEdge returns a triangle and edge index for the edge between the two
+// given vertices, or -1 if there is no such edge.
+func (tr *triangulation) findEdge(u, v int) (int, int) {
+	for _, t := range tr.around(u) {
+		tri := &tr.tris[t]
+		k := tri.vertexIndex(u)
+		switch v {
+		case tri.v[(k+1)%3]:
+			return t, (k + 2) % 3
+		case tri.v[(k+2)%3]:
+			return t, (k + 1) % 3
+		}
+	}
+	return -1, -1
+}
+
+// constrain marks the existing edge between u and v as a constraint, adding
+// the given winding weight in the direction from u to v.
+//
+// The result is false if there is no edge between u and v.
+func (tr *triangulation) constrain(u, v, weight int) bool {
+	t, i := tr.findEdge(u, v)
+	if t < 0 {
+		return false
+	}
+	tri := &tr.tris[t]
+	if tri.v[(i+1)%3] != u {
+		weight = -weight
+	}
+	tri.c[i] = true
+	tri.w[i] += weight
+	if s := tri.n[i]; s >= 0 {
+		twin := &tr.tris[s]
+		j := twin.neighborIndex(t)
+		twin.c[j] = true
+		twin.w[j] -= weight
+	}
+	return true
+}
+
+// addConstraint ensures that the triangulation has edges connecting the
+// vertices a and b, splitting the constraint at any other vertex that lies
+// on it and at any existing constraint that it crosses.
+func (tr *triangulation) addConstraint(a, b, weight int) error {
+	targets := []int{b}
+	for len(targets) > 0 {
+		b := targets[len(targets)-1]
+		if a == b {
+			targets = targets[:len(targets)-1]
+			continue
+		}
+		pa, pb := tr.pts[a], tr.pts[b]
+
+		// First we look around a for either the edge we want, a vertex
+		// lying on the constraint, or the edge the constraint passes
+		// through first.
+		end, t, i := -1, -1, -1
+		for _, at := range tr.around(a) {
+			tri := &tr.tris[at]
+			k := tri.vertexIndex(a)
+			v1, v2 := tri.v[(k+1)%3], tri.v[(k+2)%3]
+			if v1 == b || v2 == b {
+				end = b
+				break
+			}
+			o1, o2 := orient(pa, pb, tr.pts[v1]), orient(pa, pb, tr.pts[v2])
+			if o1 == 0 && ahead(pa, pb, tr.pts[v1]) {
+				end = v1
+				break
+			}
+			if o2 == 0 && ahead(pa, pb, tr.pts[v2]) {
+				end = v2
+				break
+			}
+			if o1 < 0 && o2 > 0 {
+				t, i = at, k
+				break
+			}
+		}
+		if end < 0 && t < 0 {
+			return errors.New("constraint edge leaves the triangulation")
+		}
+
+		// Now we walk across the triangulation collecting all of the edges
+		// that the constraint crosses, stopping at b or at the first vertex
+		// that lies exactly on the constraint.
+		var crossed [][2]int
+		split := false
+		for end < 0 {
+			tri := &tr.tris[t]
+			u, v := tri.v[(i+1)%3], tri.v[(i+2)%3]
+			if tri.c[i] {
+				x, ok := intersection(pa, pb, tr.pts[u], tr.pts[v])
+				if !ok {
+					return errCrossingConstraints
+				}
+				var xi int
+				switch x {
+				case tr.pts[u]:
+					xi = u
+				case tr.pts[v]:
+					xi = v
+				default:
+					xi = tr.splitEdge(t, i, x)
+				}
+				targets = append(targets, xi)
+				split = true
+				break
+			}
+			crossed = append(crossed, [2]int{u, v})
+
+			s := tri.n[i]
+			if s < 0 {
+				return errors.New("constraint edge leaves the triangulation")
+			}
+			stri := &tr.tris[s]
+			j := stri.neighborIndex(t)
+			w := stri.v[j]
+			if w == b {
+				end = b
+				break
+			}
+			switch o := orient(pa, pb, tr.pts[w]); {
+			case o == 0:
+				end = w
+			case o < 0:
+				// The edge from w to v crosses next, and it is opposite u.
+				t, i = s, (j+2)%3
+			default:
+				t, i = s, (j+1)%3
+			}
+		}
+		if split {
+			continue
+		}
+
+		if err := tr.flipCrossed(a, end, crossed); err != nil {
+			return err
+		}
+		if !tr.constrain(a, end, weight) {
+			return errors.New("failed to insert constraint edge")
+		}
+		if end == b {
+			targets = targets[:len(targets)-1]
+		}
+		a = end
+	}
+	return nil
+}
+
+// flipCrossed removes the given crossed edges by flipping them until there
+// is an edge from a to b, and then restores the Delaunay property for the
+// newly-created edges.
+//
+// This is the algorithm described by Sloan in "A fast algorithm for
+// generating constrained Delaunay triangulations".
+func (tr *triangulation) flipCrossed(a, b int, crossed [][2]int) error {
+	if len(crossed) == 0 {
+		return nil
+	}
+	pa, pb := tr.pts[a], tr.pts[b]
+	var created [][2]int
+
+	limit := len(crossed) * len(crossed) * 4
+	for len(crossed) > 0 {
+		if limit--; limit < 0 {
+			return errors.New("failed to insert constraint edge")
+		}
+		e := crossed[0]
+		crossed = crossed[1:]
+		t, i := tr.findEdge(e[0], e[1])
+		if t < 0 {
+			return errors.New("failed to insert constraint edge")
+		}
+		tri := &tr.tris[t]
+		s := tri.n[i]
+		p := tri.v[i]
+		q := tr.tris[s].v[tr.tris[s].neighborIndex(t)]
+		pp, pq := tr.pts[p], tr.pts[q]
+		if orient(pp, pq, tr.pts[e[0]])*orient(pp, pq, tr.pts[e[1]]) >= 0 {
+			// The quadrilateral is not strictly convex, so we'll come back
+			// to this edge once some others have been flipped.
+			crossed = append(crossed, e)
+			continue
+		}
+		tr.flip(t, i)
+		ne := [2]int{p, q}
+		if p != a && p != b && q != a && q != b && orient(pa, pb, pp)*orient(pa, pb, pq) < 0 {
+			crossed = append(crossed, ne)
+		} else {
+			created = append(created, ne)
+		}
+	}
+
+	for swapped := true; swapped; {
+		swapped = false
+		for k, e := range created {
+			if (e[0] == a && e[1] == b) || (e[0] == b && e[1] == a) {
+				continue
+			}
+			t, i := tr.findEdge(e[0], e[1])
+			if t < 0 {
+				continue
+			}
+			tri := &tr.tris[t]
+			s := tri.n[i]
+			if s < 0 || tri.c[i] {
+				continue
+			}
+			q := tr.tris[s].v[tr.tris[s].neighborIndex(t)]
+			if inCircle(tr.pts[tri.v[0]], tr.pts[tri.v[1]], tr.pts[tri.v[2]], tr.pts[q]) > 0 {
+				p := tri.v[i]
+				tr.flip(t, i)
+				created[k] = [2]int{p, q}
+				swapped = true
+			}
+		}
+	}
+	return nil
+}
+
+// carve removes all of the triangles whose winding number, as determined by
+// the weights of the constraint edges, does not satisfy the given function.
+// The super triangle is assumed to be outside of all constraints, with a
+// winding number of zero.
+//
+// Carving renumbers the triangles, so any previously-retained triangle
+// indices are invalid afterwards.
+func (tr *triangulation) carve(keep func(winding int) bool) {
+	winding := make([]int, len(tr.tris))
+	seen := make([]bool, len(tr.tris))
+	start := tr.vt[0]
+	queue := []int{start}
+	seen[start] = true
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		tri := &tr.tris[t]
+		for i, n := range tri.n {
+			if n < 0 || seen[n] {
+				continue
+			}
+			seen[n] = true
+			winding[n] = winding[t] - tri.w[i]
+			queue = append(queue, n)
+		}
+	}
+
+	index := make([]int, len(tr.tris))
+	kept := 0
+	for t := range tr.tris {
+		if seen[t] && keep(winding[t]) {
+			index[t] = kept
+			kept++
+		} else {
+			index[t] = -1
+		}
+	}
+
+	tris := make([]triangle, 0, kept)
+	for t, tri := range tr.tris {
+		if index[t] < 0 {
+			continue
+		}
+		for i, n := range tri.n {
+			if n >= 0 {
+				tri.n[i] = index[n]
+			}
+		}
+		tris = append(tris, tri)
+	}
+	tr.tris = tris
+	tr.last = 0
+	for i := range tr.vt {
+		tr.vt[i] = -1
+	}
+	for t, tri := range tr.tris {
+		for _, v := range tri.v {
+			tr.vt[v] = t
+		}
+	}
+}
+
+// mesh produces a Mesh from the current triangles, omitting any points that
+// are not used by at least one triangle but otherwise preserving the order
+// in which the points were added.
+//
+// The second result maps from vertex indices in the triangulation to indices
+// in the result, with -1 for any vertex that was omitted.
+func (tr *triangulation) mesh() (*Mesh, []int) {
+	index := make([]int, len(tr.pts))
+	for i := range index {
+		index[i] = -1
+	}
+	for _, tri := range tr.tris {
+		for _, v := range tri.v {
+			index[v] = 0
+		}
+	}
+	ret := &Mesh{
+		Triangles: make([][3]int, len(tr.tris)),
+	}
+	for v, p := range tr.pts {
+		if index[v] < 0 {
+			continue
+		}
+		index[v] = len(ret.Points)
+		ret.Points = append(ret.Points, p)
+	}
+	for t, tri := range tr.tris {
+		for k, v := range tri.v {
+			ret.Triangles[t][k] = index[v]
+		}
+	}
+	return ret, index
+}
+
+// ahead returns true if p, which is assumed to be on the line through a and
+// b, lies in the direction of b from a.
+func ahead(a, b, p geom.Point) bool {
+	return (b.X-a.X)*(p.X-a.X)+(b.Y-a.Y)*(p.Y-a.Y) > 0
+}
+
+// intersection returns the point where the segments a-b and c-d cross.
+//
+// The second result is false if the segments are parallel.
+func intersection(a, b, c, d geom.Point) (geom.Point, bool) {
+	den := (b.X-a.X)*(d.Y-c.Y) - (b.Y-a.Y)*(d.X-c.X)
+	if den == 0 {
+		return geom.Point{}, false
+	}
+	s := ((c.X-a.X)*(d.Y-c.Y) - (c.Y-a.Y)*(d.X-c.X)) / den
+	return geom.Point{
+		X: a.X + s*(b.X-a.X),
+		Y: a.Y + s*(b.Y-a.Y),
+	}, true
+}
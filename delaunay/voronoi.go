@@ -0,0 +1,108 @@
+package delaunay
+
+import (
+	"sort"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Voronoi returns the Voronoi diagram of the given points, clipped to the
+// given bounding rectangle.
+//
+// The result has one cell for each of the given points, at the same index,
+// containing all of the points within the bounds that are closer to that
+// point than to any other. Each cell is a convex polygon with facing -1,
+// or nil if the cell lies entirely outside of the bounds. Points that
+// appear more than once in the input all have the same cell.
+//
+// An error is returned if the points can't be triangulated, as described
+// for Triangulate.
+func Voronoi(pts []geom.Point, bounds geom.Rect) ([]geom.Poly, error) {
+	mesh, index, err := triangulatePoints(pts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The neighbors of each site in the Voronoi diagram are exactly its
+	// neighbors in the Delaunay triangulation.
+	neighbors := make([][]int, len(mesh.Points))
+	addNeighbor := func(a, b int) {
+		for _, n := range neighbors[a] {
+			if n == b {
+				return
+			}
+		}
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+	if len(mesh.Triangles) == 0 {
+		// The points are collinear, so each is a neighbor only of those
+		// immediately before and after it along the line.
+		order := make([]int, len(mesh.Points))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			a, b := mesh.Points[order[i]], mesh.Points[order[j]]
+			if a.X != b.X {
+				return a.X < b.X
+			}
+			return a.Y < b.Y
+		})
+		for i := 1; i < len(order); i++ {
+			addNeighbor(order[i-1], order[i])
+		}
+	}
+	for _, t := range mesh.Triangles {
+		for i := range t {
+			addNeighbor(t[i], t[(i+1)%3])
+		}
+	}
+
+	bounds = bounds.Normalize()
+	cells := make([]geom.Poly, len(mesh.Points))
+	for i, site := range mesh.Points {
+		cell := bounds.Poly()
+		for _, n := range neighbors[i] {
+			other := mesh.Points[n]
+			mid := site.Add(other).Scale(0.5)
+			cell = clipHalfPlane(cell, mid, other.Sub(site))
+			if cell == nil {
+				break
+			}
+		}
+		cells[i] = cell
+	}
+
+	ret := make([]geom.Poly, len(pts))
+	for i := range pts {
+		ret[i] = cells[index[i]]
+	}
+	return ret, nil
+}
+
+// clipHalfPlane returns the part of the given convex polygon that is on the
+// opposite side of the line through p to the direction n, or nil if no part
+// of the polygon is on that side.
+func clipHalfPlane(poly geom.Poly, p, n geom.Point) geom.Poly {
+	side := func(q geom.Point) float64 {
+		return (q.X-p.X)*n.X + (q.Y-p.Y)*n.Y
+	}
+
+	ret := make(geom.Poly, 0, len(poly)+1)
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		sa, sb := side(a), side(b)
+		if sa <= 0 {
+			ret = append(ret, a)
+		}
+		if (sa < 0 && sb > 0) || (sa > 0 && sb < 0) {
+			t := sa / (sa - sb)
+			ret = append(ret, a.Add(b.Sub(a).Scale(t)))
+		}
+	}
+	if len(ret) < 3 {
+		return nil
+	}
+	return ret
+}
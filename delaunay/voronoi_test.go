@@ -0,0 +1,86 @@
+package delaunay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+func TestVoronoi(t *testing.T) {
+	bounds := geom.Rect{{10, 10}, {-10, -10}}
+
+	tests := []struct {
+		Name   string
+		Points []geom.Point
+	}{
+		{
+			Name:   "single",
+			Points: []geom.Point{{1, 2}},
+		},
+		{
+			Name:   "collinear",
+			Points: []geom.Point{{-5, -5}, {0, 0}, {5, 5}},
+		},
+		{
+			Name:   "grid",
+			Points: grid(5, 4),
+		},
+		{
+			Name:   "scattered",
+			Points: []geom.Point{{-7, 3}, {2, 8}, {4, -6}, {-1, -1}, {6, 1}, {-3, -8}, {2, 8}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := Voronoi(test.Points, bounds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(test.Points) {
+				t.Fatalf("wrong number of cells %d; want %d", len(got), len(test.Points))
+			}
+
+			seen := map[geom.Point]bool{}
+			var area float64
+			for i, cell := range got {
+				site := test.Points[i]
+				if cell == nil {
+					t.Errorf("cell %d is nil", i)
+					continue
+				}
+				if !seen[site] {
+					seen[site] = true
+					for j := range cell {
+						a := orient(cell[j], cell[(j+1)%len(cell)], geom.Point{})
+						area += a / 2
+						if orient(cell[j], cell[(j+1)%len(cell)], site) < 0 {
+							t.Errorf("cell %d does not contain its site %#v", i, site)
+						}
+					}
+				}
+
+				// Every vertex of the cell must be at least as close to
+				// this site as to any other.
+				for _, v := range cell {
+					d := dist(v, site)
+					for _, other := range test.Points {
+						if dist(v, other) < d-1e-9 {
+							t.Errorf("cell %d vertex %#v is closer to %#v than to %#v", i, v, other, site)
+						}
+					}
+				}
+			}
+
+			// The cells must exactly cover the bounds.
+			if want := 400.0; math.Abs(area-want) > 1e-9 {
+				t.Errorf("wrong total area %f; want %f", area, want)
+			}
+		})
+	}
+}
+
+func dist(a, b geom.Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
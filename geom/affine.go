@@ -0,0 +1,102 @@
+package geom
+
+import (
+	"math"
+)
+
+// Affine represents an affine transformation of the plane, as the matrix
+//
+//	| A[0] A[2] A[4] |
+//	| A[1] A[3] A[5] |
+//	|  0    0    1   |
+//
+// The elements are in the same order as the arguments of the matrix
+// function in SVG transform attributes.
+type Affine [6]float64
+
+// IdentityAffine is the transformation that leaves all points unchanged.
+var IdentityAffine = Affine{1, 0, 0, 1, 0, 0}
+
+// TranslateAffine returns a transformation that moves points by the given
+// offset.
+func TranslateAffine(d Point) Affine {
+	return Affine{1, 0, 0, 1, d.X, d.Y}
+}
+
+// ScaleAffine returns a transformation that scales points about the origin
+// by the given factor in each dimension.
+func ScaleAffine(s Point) Affine {
+	return Affine{s.X, 0, 0, s.Y, 0, 0}
+}
+
+// RotateAffine returns a transformation that rotates points about the
+// origin by the given angle in radians, in the same direction as
+// Point.Rotate.
+func RotateAffine(angle float64) Affine {
+	sin, cos := math.Sincos(angle)
+	return Affine{cos, sin, -sin, cos, 0, 0}
+}
+
+// Then returns the transformation that applies the receiver followed by the
+// given other transformation.
+func (a Affine) Then(b Affine) Affine {
+	return Affine{
+		b[0]*a[0] + b[2]*a[1],
+		b[1]*a[0] + b[3]*a[1],
+		b[0]*a[2] + b[2]*a[3],
+		b[1]*a[2] + b[3]*a[3],
+		b[0]*a[4] + b[2]*a[5] + b[4],
+		b[1]*a[4] + b[3]*a[5] + b[5],
+	}
+}
+
+// Apply returns the result of transforming the given point.
+func (a Affine) Apply(p Point) Point {
+	return Point{
+		X: a[0]*p.X + a[2]*p.Y + a[4],
+		Y: a[1]*p.X + a[3]*p.Y + a[5],
+	}
+}
+
+// ApplyVector returns the result of transforming the given vector, which is
+// the same as for Apply except that the translation is ignored.
+func (a Affine) ApplyVector(v Point) Point {
+	return Point{
+		X: a[0]*v.X + a[2]*v.Y,
+		Y: a[1]*v.X + a[3]*v.Y,
+	}
+}
+
+// Det returns the determinant of the transformation, which is the factor by
+// which it scales areas. It is negative if the transformation reflects.
+func (a Affine) Det() float64 {
+	return a[0]*a[3] - a[1]*a[2]
+}
+
+// Invert returns the inverse of the transformation. The second result is
+// false if the transformation has no inverse, because its determinant is
+// zero.
+func (a Affine) Invert() (Affine, bool) {
+	det := a.Det()
+	if det == 0 {
+		return Affine{}, false
+	}
+	inv := Affine{
+		a[3] / det,
+		-a[1] / det,
+		-a[2] / det,
+		a[0] / det,
+	}
+	inv[4] = -(inv[0]*a[4] + inv[2]*a[5])
+	inv[5] = -(inv[1]*a[4] + inv[3]*a[5])
+	return inv, true
+}
+
+// MaxScale returns the largest factor by which the transformation scales
+// the length of any vector.
+func (a Affine) MaxScale() float64 {
+	// This is the largest singular value of the linear part.
+	s := (a[0]*a[0] + a[1]*a[1] + a[2]*a[2] + a[3]*a[3]) / 2
+	d := a.Det()
+	return math.Sqrt(s + math.Sqrt(math.Max(0, s*s-d*d)))
+}
@@ -0,0 +1,86 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestAffine(t *testing.T) {
+	tests := []struct {
+		Affine Affine
+		Point  Point
+		Want   Point
+	}{
+		{
+			IdentityAffine,
+			Point{2, 3},
+			Point{2, 3},
+		},
+		{
+			TranslateAffine(Point{1, -1}),
+			Point{2, 3},
+			Point{3, 2},
+		},
+		{
+			ScaleAffine(Point{2, -1}),
+			Point{2, 3},
+			Point{4, -3},
+		},
+		{
+			RotateAffine(math.Pi / 2),
+			Point{2, 3},
+			Point{-3, 2},
+		},
+		{
+			// Scale first, then translate
+			ScaleAffine(Point{2, 2}).Then(TranslateAffine(Point{1, 0})),
+			Point{2, 3},
+			Point{5, 6},
+		},
+		{
+			// Translate first, then scale
+			TranslateAffine(Point{1, 0}).Then(ScaleAffine(Point{2, 2})),
+			Point{2, 3},
+			Point{6, 6},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Affine), func(t *testing.T) {
+			got := test.Affine.Apply(test.Point)
+			if !got.ApproxEqual(test.Want, 1e-12) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+
+			inv, ok := test.Affine.Invert()
+			if !ok {
+				t.Fatalf("no inverse")
+			}
+			if back := inv.Apply(got); !back.ApproxEqual(test.Point, 1e-12) {
+				t.Errorf("wrong inverse result\ngot:  %#v\nwant: %#v", back, test.Point)
+			}
+		})
+	}
+}
+
+func TestAffineMaxScale(t *testing.T) {
+	tests := []struct {
+		Affine Affine
+		Want   float64
+	}{
+		{IdentityAffine, 1},
+		{ScaleAffine(Point{2, -3}), 3},
+		{RotateAffine(1).Then(ScaleAffine(Point{0.5, 0.25})), 0.5},
+		{Affine{1, 0, 1, 1, 0, 0}, (1 + math.Sqrt(5)) / 2},
+		{Affine{}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Affine), func(t *testing.T) {
+			if got := test.Affine.MaxScale(); math.Abs(got-test.Want) > 1e-12 {
+				t.Errorf("wrong result %g; want %g", got, test.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package geom
+
+import (
+	"math"
+)
+
+// Arc represents part of an ellipse, described by its endpoints in the same
+// way as the arc instructions of an SVG path.
+//
+// Of the four arcs of a given ellipse that could join the two endpoints,
+// LargeArc selects one that turns through more than half of a full turn,
+// and Sweep selects one that travels in the direction of increasing angle,
+// which is anti-clockwise assuming a Y axis that increases upward. If the
+// radii are too small for any ellipse to join the endpoints then they are
+// scaled up uniformly until exactly one can, as SVG requires.
+type Arc struct {
+	From, To Point
+
+	// Radii gives the radii of the ellipse along its own X and Y axes.
+	// Negative radii are treated as positive, and if either radius is zero
+	// then the arc is a straight line.
+	Radii Point
+
+	// Rotation is the angle in radians from the X axis of the coordinate
+	// space to the X axis of the ellipse.
+	Rotation float64
+
+	LargeArc, Sweep bool
+}
+
+// CubicCurves approximates the arc using cubic bezier curves, with one
+// curve for each quarter-turn or part thereof. The result is empty if the
+// endpoints of the arc are equal.
+//
+// The conversion follows the rules in the implementation notes of the SVG
+// specification, including the scaling of radii that are too small to reach
+// the endpoint.
+func (a Arc) CubicCurves() []CubicCurve {
+	e, ok := a.ellipse()
+	if !ok {
+		if a.From == a.To {
+			return nil
+		}
+		return []CubicCurve{LineSeg{a.From, a.To}.CubicCurve()}
+	}
+
+	n := int(math.Ceil(math.Abs(e.delta)/(math.Pi/2) - 1e-9))
+	if n < 1 {
+		n = 1
+	}
+	step := e.delta / float64(n)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	ret := make([]CubicCurve, n)
+	p0 := a.From
+	_, d0 := e.at(e.start)
+	for i := 0; i < n; i++ {
+		p1, d1 := e.at(e.start + step*float64(i+1))
+		if i == n-1 {
+			p1 = a.To
+		}
+		ret[i] = CubicCurve{
+			p0,
+			p0.Add(d0.Scale(k)),
+			p1.Sub(d1.Scale(k)),
+			p1,
+		}
+		p0, d0 = p1, d1
+	}
+	return ret
+}
+
+// Bounds returns the smallest normalized rectangle containing the arc.
+func (a Arc) Bounds() Rect {
+	r := Rect{a.From, a.To}.Normalize()
+	e, ok := a.ellipse()
+	if !ok {
+		return r
+	}
+
+	// The ellipse reaches its extremes along each axis at two opposite
+	// angles, which we include if they fall within the arc.
+	xTheta := math.Atan2(-e.ry*e.sin, e.rx*e.cos)
+	yTheta := math.Atan2(e.ry*e.cos, e.rx*e.sin)
+	lo, hi := e.start, e.start+e.delta
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	for _, theta := range []float64{xTheta, xTheta + math.Pi, yTheta, yTheta + math.Pi} {
+		// Move theta to the first equivalent angle that isn't less than
+		// the start of the range.
+		theta = lo + math.Mod(math.Mod(theta-lo, 2*math.Pi)+2*math.Pi, 2*math.Pi)
+		if theta <= hi {
+			p, _ := e.at(theta)
+			r = r.Union(p.Bounds())
+		}
+	}
+	return r
+}
+
+// arcEllipse is the center parameterization of an Arc.
+type arcEllipse struct {
+	center       Point
+	rx, ry       float64
+	sin, cos     float64
+	start, delta float64
+}
+
+// ellipse converts the arc to its center parameterization. The second
+// result is false if the arc is degenerate, because its endpoints are equal
+// or one of its radii is zero.
+func (a Arc) ellipse() (arcEllipse, bool) {
+	from, to := a.From, a.To
+	if from == to {
+		return arcEllipse{}, false
+	}
+	rx, ry := math.Abs(a.Radii.X), math.Abs(a.Radii.Y)
+	if rx == 0 || ry == 0 {
+		return arcEllipse{}, false
+	}
+
+	sin, cos := math.Sincos(a.Rotation)
+
+	// Transform the midpoint between the endpoints into a coordinate space
+	// where the ellipse axes are aligned with the X and Y axes.
+	dx, dy := (from.X-to.X)/2, (from.Y-to.Y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	if l := x1*x1/(rx*rx) + y1*y1/(ry*ry); l > 1 {
+		s := math.Sqrt(l)
+		rx *= s
+		ry *= s
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if a.LargeArc == a.Sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	center := Point{
+		X: cos*cx1 - sin*cy1 + (from.X+to.X)/2,
+		Y: sin*cx1 + cos*cy1 + (from.Y+to.Y)/2,
+	}
+
+	u := Point{X: (x1 - cx1) / rx, Y: (y1 - cy1) / ry}
+	v := Point{X: (-x1 - cx1) / rx, Y: (-y1 - cy1) / ry}
+	start := u.Angle()
+	delta := u.AngleBetween(v)
+	switch {
+	case !a.Sweep && delta > 0:
+		delta -= 2 * math.Pi
+	case a.Sweep && delta < 0:
+		delta += 2 * math.Pi
+	}
+	return arcEllipse{center, rx, ry, sin, cos, start, delta}, true
+}
+
+// at returns the point on the ellipse at the given angle, along with the
+// derivative of the position with respect to the angle.
+func (e arcEllipse) at(theta float64) (Point, Point) {
+	s, c := math.Sincos(theta)
+	pt := Point{
+		X: e.center.X + e.cos*e.rx*c - e.sin*e.ry*s,
+		Y: e.center.Y + e.sin*e.rx*c + e.cos*e.ry*s,
+	}
+	deriv := Point{
+		X: -e.cos*e.rx*s - e.sin*e.ry*c,
+		Y: -e.sin*e.rx*s + e.cos*e.ry*c,
+	}
+	return pt, deriv
+}
@@ -0,0 +1,72 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		Shape Bounder
+		Want  Rect
+	}{
+		{
+			Point{1, 2},
+			Rect{{1, 2}, {1, 2}},
+		},
+		{
+			LineSeg{{3, 0}, {1, 2}},
+			Rect{{1, 0}, {3, 2}},
+		},
+		{
+			Tri{{0, 0}, {4, 1}, {2, 3}},
+			Rect{{0, 0}, {4, 3}},
+		},
+		{
+			Poly{{0, 0}, {4, -1}, {5, 2}, {-1, 3}},
+			Rect{{-1, -1}, {5, 3}},
+		},
+		{
+			Rect{{4, 4}, {0, 0}},
+			Rect{{0, 0}, {4, 4}},
+		},
+		{
+			LineSegSeq{{0, 0}, {2, 5}, {-3, 1}},
+			Rect{{-3, 0}, {2, 5}},
+		},
+		{
+			// The curve doesn't reach as far as its control points.
+			CubicCurve{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+			Rect{{0, 0}, {10, 7.5}},
+		},
+		{
+			QuadraticCurve{{0, 0}, {5, 10}, {10, 0}},
+			Rect{{0, 0}, {10, 5}},
+		},
+		{
+			CubicCurveSeq{
+				{0, 0},
+				{0, 10}, {10, 10}, {10, 0},
+				{10, -10}, {20, -10}, {20, 0},
+			},
+			Rect{{0, -7.5}, {20, 7.5}},
+		},
+		{
+			Region{
+				{Outer: Poly{{0, 0}, {1, 0}, {1, 1}}},
+				{Outer: Poly{{5, 5}, {6, 5}, {6, 7}}},
+			},
+			Rect{{0, 0}, {6, 7}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Shape), func(t *testing.T) {
+			for _, problem := range deep.Equal(test.Shape.Bounds(), test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
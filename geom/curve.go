@@ -1,6 +1,11 @@
 package geom
 
-const twoThirds = 2 / 3
+import (
+	"math"
+	"sort"
+)
+
+const twoThirds = 2.0 / 3.0
 
 // CubicCurve represents a cubic bezier curve.
 type CubicCurve [4]Point
@@ -11,15 +16,292 @@ func (c CubicCurve) CubicCurve() CubicCurve {
 	return c
 }
 
+// Bounds returns the smallest normalized rectangle containing the curve.
+//
+// This is usually smaller than the rectangle containing the control points,
+// since the curve only passes through its endpoints.
+func (c CubicCurve) Bounds() Rect {
+	r := Rect{c[0], c[3]}.Normalize()
+	for _, t := range c.extrema() {
+		r = r.Union(c.At(t).Bounds())
+	}
+	return r
+}
+
+// At returns the point on the curve at the given parameter, which ranges
+// from zero at the start of the curve to one at its end.
+func (c CubicCurve) At(t float64) Point {
+	mt := 1 - t
+	return c[0].Scale(mt * mt * mt).
+		Add(c[1].Scale(3 * mt * mt * t)).
+		Add(c[2].Scale(3 * mt * t * t)).
+		Add(c[3].Scale(t * t * t))
+}
+
+// Derivative returns the first derivative of the curve with respect to its
+// parameter, which is a vector in the direction of travel along the curve
+// whose length is the speed of travel.
+func (c CubicCurve) Derivative(t float64) Point {
+	mt := 1 - t
+	return c[1].Sub(c[0]).Scale(3 * mt * mt).
+		Add(c[2].Sub(c[1]).Scale(6 * mt * t)).
+		Add(c[3].Sub(c[2]).Scale(3 * t * t))
+}
+
+// SecondDerivative returns the second derivative of the curve with respect
+// to its parameter.
+func (c CubicCurve) SecondDerivative(t float64) Point {
+	a := c[2].Sub(c[1].Scale(2)).Add(c[0])
+	b := c[3].Sub(c[2].Scale(2)).Add(c[1])
+	return a.Scale(6 * (1 - t)).Add(b.Scale(6 * t))
+}
+
+// Tangent returns a vector of length one in the direction of travel along
+// the curve at the given parameter.
+//
+// Where the first derivative is zero, such as at an endpoint that coincides
+// with its adjacent control point, the direction is instead taken from the
+// second derivative, and failing that from the start of the curve to its
+// end. The result is the origin only if all of the points of the curve are
+// equal.
+func (c CubicCurve) Tangent(t float64) Point {
+	return tangent(c.Derivative(t), c.SecondDerivative(t), c[3].Sub(c[0]))
+}
+
+// Normal returns a vector of length one perpendicular to the curve at the
+// given parameter, pointing to the left of the direction of travel
+// assuming a Y axis that increases upward.
+func (c CubicCurve) Normal(t float64) Point {
+	return c.Tangent(t).Perp()
+}
+
+// Curvature returns the signed curvature of the curve at the given
+// parameter, which is the reciprocal of the radius of the circle that best
+// fits the curve at that point. The curvature is positive where the curve
+// turns anti-clockwise, assuming a Y axis that increases upward.
+//
+// The result is zero where the first derivative is zero, since the
+// curvature is undefined there.
+func (c CubicCurve) Curvature(t float64) float64 {
+	return curvature(c.Derivative(t), c.SecondDerivative(t))
+}
+
+// Split divides the curve at the given parameter using de Casteljau's
+// algorithm, returning curves that represent the parts before and after
+// that parameter.
+func (c CubicCurve) Split(t float64) (CubicCurve, CubicCurve) {
+	p01, p12, p23 := c[0].Lerp(c[1], t), c[1].Lerp(c[2], t), c[2].Lerp(c[3], t)
+	p012, p123 := p01.Lerp(p12, t), p12.Lerp(p23, t)
+	mid := p012.Lerp(p123, t)
+	return CubicCurve{c[0], p01, p012, mid}, CubicCurve{mid, p123, p23, c[3]}
+}
+
+// SubCurve returns the part of the curve between the two given parameters.
+// If t0 is greater than t1 then the result runs in the opposite direction
+// to the receiver.
+func (c CubicCurve) SubCurve(t0, t1 float64) CubicCurve {
+	if t0 > t1 {
+		r := c.SubCurve(t1, t0)
+		return CubicCurve{r[3], r[2], r[1], r[0]}
+	}
+	before, _ := c.Split(t1)
+	if t1 == 0 {
+		return before
+	}
+	_, ret := before.Split(t0 / t1)
+	return ret
+}
+
+// Flatten approximates the curve as a sequence of line segments that are
+// nowhere further than the given tolerance from the curve. The result
+// begins at the start of the curve and ends at its end.
+//
+// A curve whose control points are all within the tolerance of the line
+// between its endpoints becomes a single segment. Otherwise the segments
+// are evenly spaced by parameter, with their number chosen using Wang's
+// formula, which bounds the distance between the curve and the polyline
+// through its points at that spacing. The tolerance must be greater than
+// zero.
+func (c CubicCurve) Flatten(tolerance float64) LineSegSeq {
+	n := c.flattenCount(tolerance)
+	ret := BeginLineSegSeq(c[0], n)
+	for i := 1; i < n; i++ {
+		ret = ret.Append(c.At(float64(i) / float64(n)))
+	}
+	return ret.Append(c[3])
+}
+
+// flattenCount returns the number of segments that method Flatten uses to
+// approximate the curve.
+func (c CubicCurve) flattenCount(tolerance float64) int {
+	// The curve lies within the convex hull of its control points, so a
+	// single segment is enough if both inner control points are close to
+	// it. This is common for straight lines converted to curves, whose
+	// control points may be unevenly spaced along the line.
+	chord := LineSeg{c[0], c[3]}
+	if segDist(chord, c[1]) <= tolerance && segDist(chord, c[2]) <= tolerance {
+		return 1
+	}
+
+	// Wang's formula: the polyline through n+1 evenly spaced points on a
+	// curve of degree d is no further from the curve than
+	// d(d-1)/8 * m / n^2, where m is the length of the largest second
+	// difference of the control points.
+	m := math.Max(
+		c[0].Sub(c[1].Scale(2)).Add(c[2]).Len(),
+		c[1].Sub(c[2].Scale(2)).Add(c[3]).Len(),
+	)
+	n := math.Ceil(math.Sqrt(0.75 * m / tolerance))
+	// The limit guards against tolerances too small to be meaningful,
+	// which would otherwise produce an enormous number of segments.
+	return int(math.Max(1, math.Min(n, maxFlattenSegments)))
+}
+
+// maxFlattenSegments is the largest number of segments that Flatten will
+// produce for a single curve.
+const maxFlattenSegments = 1 << 16
+
+// extrema returns the parameters, in increasing order, strictly between zero
+// and one where the curve reaches a local minimum or maximum on either axis.
+func (c CubicCurve) extrema() []float64 {
+	var ret []float64
+	axis := func(p0, p1, p2, p3 float64) {
+		// The derivative on each axis is a quadratic polynomial.
+		a := p3 - 3*p2 + 3*p1 - p0
+		b := 2 * (p2 - 2*p1 + p0)
+		c := p1 - p0
+		for _, t := range quadraticRoots(a, b, c) {
+			if t > 0 && t < 1 {
+				ret = append(ret, t)
+			}
+		}
+	}
+	axis(c[0].X, c[1].X, c[2].X, c[3].X)
+	axis(c[0].Y, c[1].Y, c[2].Y, c[3].Y)
+	sort.Float64s(ret)
+	return ret
+}
+
+// flat returns true if both control points of the curve are within the
+// given distance of the line through its endpoints.
+func (c CubicCurve) flat(tolerance float64) bool {
+	d := c[3].Sub(c[0])
+	l := d.Len()
+	if l == 0 {
+		return c[0].Dist(c[1]) <= tolerance && c[0].Dist(c[2]) <= tolerance
+	}
+	limit := tolerance * l
+	return math.Abs(orient(c[0], c[3], c[1])) <= limit && math.Abs(orient(c[0], c[3], c[2])) <= limit
+}
+
+// appendHullPoints appends to dst the control points of pieces of the curve
+// that are each flat within the given tolerance, after first splitting the
+// curve at its extrema.
+func (c CubicCurve) appendHullPoints(dst []Point, tolerance float64) []Point {
+	rest := c
+	prev := 0.0
+	for _, t := range c.extrema() {
+		var piece CubicCurve
+		piece, rest = rest.Split((t - prev) / (1 - prev))
+		prev = t
+		dst = piece.appendFlatPoints(dst, tolerance, 0)
+	}
+	return rest.appendFlatPoints(dst, tolerance, 0)
+}
+
+func (c CubicCurve) appendFlatPoints(dst []Point, tolerance float64, depth int) []Point {
+	// The depth limit guards against tolerances too small to ever reach
+	// using floating point arithmetic.
+	if depth >= 16 || c.flat(tolerance) {
+		return append(dst, c[:]...)
+	}
+	a, b := c.Split(0.5)
+	dst = a.appendFlatPoints(dst, tolerance, depth+1)
+	return b.appendFlatPoints(dst, tolerance, depth+1)
+}
+
 // QuadraticCurve represents a quadratic bezier curve.
 type QuadraticCurve [3]Point
 
+// Bounds returns the smallest normalized rectangle containing the curve.
+func (c QuadraticCurve) Bounds() Rect {
+	return c.CubicCurve().Bounds()
+}
+
+// At returns the point on the curve at the given parameter, which ranges
+// from zero at the start of the curve to one at its end.
+func (c QuadraticCurve) At(t float64) Point {
+	mt := 1 - t
+	return c[0].Scale(mt * mt).
+		Add(c[1].Scale(2 * mt * t)).
+		Add(c[2].Scale(t * t))
+}
+
+// Derivative returns the first derivative of the curve with respect to its
+// parameter, which is a vector in the direction of travel along the curve
+// whose length is the speed of travel.
+func (c QuadraticCurve) Derivative(t float64) Point {
+	return c[1].Sub(c[0]).Scale(2 * (1 - t)).Add(c[2].Sub(c[1]).Scale(2 * t))
+}
+
+// SecondDerivative returns the second derivative of the curve with respect
+// to its parameter, which is the same at all points along a quadratic
+// curve.
+func (c QuadraticCurve) SecondDerivative(t float64) Point {
+	return c[2].Sub(c[1].Scale(2)).Add(c[0]).Scale(2)
+}
+
+// Tangent returns a vector of length one in the direction of travel along
+// the curve at the given parameter, falling back on other directions where
+// the first derivative is zero as described for CubicCurve.Tangent.
+func (c QuadraticCurve) Tangent(t float64) Point {
+	return tangent(c.Derivative(t), c.SecondDerivative(t), c[2].Sub(c[0]))
+}
+
+// Normal returns a vector of length one perpendicular to the curve at the
+// given parameter, pointing to the left of the direction of travel
+// assuming a Y axis that increases upward.
+func (c QuadraticCurve) Normal(t float64) Point {
+	return c.Tangent(t).Perp()
+}
+
+// Curvature returns the signed curvature of the curve at the given
+// parameter, as described for CubicCurve.Curvature.
+func (c QuadraticCurve) Curvature(t float64) float64 {
+	return curvature(c.Derivative(t), c.SecondDerivative(t))
+}
+
+// Split divides the curve at the given parameter using de Casteljau's
+// algorithm, returning curves that represent the parts before and after
+// that parameter.
+func (c QuadraticCurve) Split(t float64) (QuadraticCurve, QuadraticCurve) {
+	p01, p12 := c[0].Lerp(c[1], t), c[1].Lerp(c[2], t)
+	mid := p01.Lerp(p12, t)
+	return QuadraticCurve{c[0], p01, mid}, QuadraticCurve{mid, p12, c[2]}
+}
+
+// SubCurve returns the part of the curve between the two given parameters.
+// If t0 is greater than t1 then the result runs in the opposite direction
+// to the receiver.
+func (c QuadraticCurve) SubCurve(t0, t1 float64) QuadraticCurve {
+	if t0 > t1 {
+		r := c.SubCurve(t1, t0)
+		return QuadraticCurve{r[2], r[1], r[0]}
+	}
+	before, _ := c.Split(t1)
+	if t1 == 0 {
+		return before
+	}
+	_, ret := before.Split(t0 / t1)
+	return ret
+}
+
 // CubicCurve returns a CubicCurve equivalent to the receiver.
 func (c QuadraticCurve) CubicCurve() CubicCurve {
 	return CubicCurve{
 		c[0],
 		c[0].Add(c[1].Sub(c[0]).Scale(twoThirds)),
-		c[0].Add(c[1].Sub(c[2]).Scale(twoThirds)),
+		c[2].Add(c[1].Sub(c[2]).Scale(twoThirds)),
 		c[2],
 	}
 }
@@ -28,3 +310,46 @@ func (c QuadraticCurve) CubicCurve() CubicCurve {
 type CubicCurver interface {
 	CubicCurve() CubicCurve
 }
+
+// tangent returns the direction of the first of the given vectors that
+// isn't zero, as a vector of length one.
+func tangent(dirs ...Point) Point {
+	for _, d := range dirs {
+		if d != Origin {
+			return d.Normalize()
+		}
+	}
+	return Origin
+}
+
+// curvature returns the signed curvature of a curve with the given first
+// and second derivatives, or zero if the first derivative is zero.
+func curvature(d1, d2 Point) float64 {
+	l := d1.Len()
+	if l == 0 {
+		return 0
+	}
+	return d1.Cross(d2) / (l * l * l)
+}
+
+// quadraticRoots returns the real roots of the polynomial a*t*t + b*t + c,
+// which may be linear or constant if a or b are zero.
+func quadraticRoots(a, b, c float64) []float64 {
+	if a == 0 {
+		if b == 0 {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+	disc := b*b - 4*a*c
+	switch {
+	case disc < 0:
+		return nil
+	case disc == 0:
+		return []float64{-b / (2 * a)}
+	}
+	// This form avoids cancellation when b is close to the square root of
+	// the discriminant.
+	q := -0.5 * (b + math.Copysign(math.Sqrt(disc), b))
+	return []float64{q / a, c / q}
+}
@@ -1,5 +1,9 @@
 package geom
 
+import (
+	"iter"
+)
+
 // CubicCurveSeq represents a sequence of connected cubic bezier curves.
 //
 // The internal representation is a slice of points: a start point, followed
@@ -27,12 +31,62 @@ func (s CubicCurveSeq) Append(c1, c2, end Point) CubicCurveSeq {
 	return append(s, c1, c2, end)
 }
 
+// Bounds returns the smallest normalized rectangle containing all of the
+// curves in the sequence. See the Bounds method of CubicCurve for details.
+func (s CubicCurveSeq) Bounds() Rect {
+	if len(s) == 0 {
+		return ZeroRect
+	}
+	r := s[0].Bounds()
+	it := s.Iterator()
+	for it.Next() {
+		r = r.Union(it.CubicCurve().Bounds())
+	}
+	return r
+}
+
+// Flatten approximates the sequence of curves as a sequence of line
+// segments that are nowhere further than the given tolerance from the
+// curves, as described for CubicCurve.Flatten. The endpoint of each curve is
+// included in the result.
+//
+// The result is nil if the receiver is empty.
+func (s CubicCurveSeq) Flatten(tolerance float64) LineSegSeq {
+	if len(s) == 0 {
+		return nil
+	}
+	n := 0
+	it := s.Iterator()
+	for it.Next() {
+		n += it.CubicCurve().flattenCount(tolerance)
+	}
+	ret := BeginLineSegSeq(s[0], n)
+	it = s.Iterator()
+	for it.Next() {
+		ret = append(ret, it.CubicCurve().Flatten(tolerance)[1:]...)
+	}
+	return ret
+}
+
 // Iterator returns an interator over the curve segments in the receiving
 // sequence.
 func (s CubicCurveSeq) Iterator() CubicCurveIterator {
 	return &cubicCurveSeqIter{
 		seq: s,
-		pos: -1,
+		pos: -3,
+	}
+}
+
+// Curves returns an iterator over the curve segments in the receiving
+// sequence, for use with a range statement.
+func (s CubicCurveSeq) Curves() iter.Seq[CubicCurve] {
+	return func(yield func(CubicCurve) bool) {
+		it := s.Iterator()
+		for it.Next() {
+			if !yield(it.CubicCurve()) {
+				return
+			}
+		}
 	}
 }
 
@@ -50,11 +104,11 @@ type cubicCurveSeqIter struct {
 
 func (i *cubicCurveSeqIter) Next() bool {
 	i.pos += 3
-	return i.pos <= (len(i.seq) - 1)
+	return i.pos+3 <= (len(i.seq) - 1)
 }
 
 func (i *cubicCurveSeqIter) CubicCurve() CubicCurve {
-	s := i.seq[i.pos:]
+	s := i.seq[i.pos : i.pos+4]
 	var ret CubicCurve
 	copy(ret[:], s)
 	return ret
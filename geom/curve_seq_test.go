@@ -0,0 +1,42 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCubicCurveSeqIterator(t *testing.T) {
+	tests := []struct {
+		Seq  CubicCurveSeq
+		Want []CubicCurve
+	}{
+		{nil, nil},
+		{BeginCubicCurveSeq(Point{0, 0}, 1), nil},
+		{
+			CubicCurveSeq{{0, 0}, {0, 1}, {1, 1}, {1, 0}},
+			[]CubicCurve{{{0, 0}, {0, 1}, {1, 1}, {1, 0}}},
+		},
+		{
+			CubicCurveSeq{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {2, -1}, {2, 0}},
+			[]CubicCurve{
+				{{0, 0}, {0, 1}, {1, 1}, {1, 0}},
+				{{1, 0}, {1, -1}, {2, -1}, {2, 0}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Seq), func(t *testing.T) {
+			it := test.Seq.Iterator()
+			var got []CubicCurve
+			for it.Next() {
+				got = append(got, it.CubicCurve())
+			}
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
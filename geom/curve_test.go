@@ -0,0 +1,233 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCubicCurveEval(t *testing.T) {
+	// A cubic approximating a quarter of the unit circle, anti-clockwise
+	// from (1, 0) to (0, 1).
+	const k = 0.5522847498
+	arc := CubicCurve{{1, 0}, {1, k}, {k, 1}, {0, 1}}
+	const eps = 1e-9
+
+	tests := []struct {
+		Name string
+		Got  Point
+		Want Point
+	}{
+		{"At start", arc.At(0), Point{1, 0}},
+		{"At end", arc.At(1), Point{0, 1}},
+		{"At middle", arc.At(0.5), Point{0.7071067811, 0.7071067811}},
+		{"Derivative start", arc.Derivative(0), Point{0, 3 * k}},
+		{"Derivative end", arc.Derivative(1), Point{-3 * k, 0}},
+		{"SecondDerivative start", arc.SecondDerivative(0), Point{6 * (k - 1), 6 * (1 - 2*k)}},
+		{"Tangent start", arc.Tangent(0), Point{0, 1}},
+		{"Tangent end", arc.Tangent(1), Point{-1, 0}},
+		{"Normal start", arc.Normal(0), Point{-1, 0}},
+		{
+			"Tangent at cusp",
+			CubicCurve{{0, 0}, {0, 0}, {1, 1}, {2, 1}}.Tangent(0),
+			Point{math.Sqrt2 / 2, math.Sqrt2 / 2},
+		},
+		{
+			"Tangent of point",
+			CubicCurve{{1, 1}, {1, 1}, {1, 1}, {1, 1}}.Tangent(0.5),
+			Origin,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if !test.Got.ApproxEqual(test.Want, eps) {
+				t.Errorf("wrong result %#v; want %#v", test.Got, test.Want)
+			}
+		})
+	}
+
+	t.Run("Curvature", func(t *testing.T) {
+		for _, u := range []float64{0, 0.25, 0.5, 1} {
+			// The curve isn't exactly circular, so this is only close.
+			if got := arc.Curvature(u); math.Abs(got-1) > 0.03 {
+				t.Errorf("wrong curvature at %g: %g; want 1", u, got)
+			}
+		}
+		rev := CubicCurve{arc[3], arc[2], arc[1], arc[0]}
+		if got := rev.Curvature(0.5); math.Abs(got+1) > 0.03 {
+			t.Errorf("wrong reversed curvature %g; want -1", got)
+		}
+		line := LineSeg{{0, 0}, {3, 4}}.CubicCurve()
+		if got := line.Curvature(0.3); got != 0 {
+			t.Errorf("wrong line curvature %g; want 0", got)
+		}
+	})
+}
+
+func TestCubicCurveSplit(t *testing.T) {
+	c := CubicCurve{{0, 0}, {1, 3}, {4, 3}, {6, -1}}
+	const eps = 1e-12
+
+	for _, split := range []float64{0, 0.3, 0.5, 1} {
+		t.Run(fmt.Sprintf("Split(%g)", split), func(t *testing.T) {
+			a, b := c.Split(split)
+			for _, u := range []float64{0, 0.25, 0.5, 0.75, 1} {
+				if got, want := a.At(u), c.At(u*split); !got.ApproxEqual(want, eps) {
+					t.Errorf("wrong first part at %g: %#v; want %#v", u, got, want)
+				}
+				if got, want := b.At(u), c.At(split+u*(1-split)); !got.ApproxEqual(want, eps) {
+					t.Errorf("wrong second part at %g: %#v; want %#v", u, got, want)
+				}
+			}
+		})
+	}
+
+	for _, r := range [][2]float64{{0.2, 0.7}, {0, 0.4}, {0.6, 1}, {0.8, 0.1}, {0, 0}} {
+		t.Run(fmt.Sprintf("SubCurve(%g, %g)", r[0], r[1]), func(t *testing.T) {
+			sub := c.SubCurve(r[0], r[1])
+			for _, u := range []float64{0, 0.25, 0.5, 0.75, 1} {
+				want := c.At(r[0] + u*(r[1]-r[0]))
+				if got := sub.At(u); !got.ApproxEqual(want, eps) {
+					t.Errorf("wrong point at %g: %#v; want %#v", u, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestQuadraticCurveCubicCurve(t *testing.T) {
+	tests := []struct {
+		Curve QuadraticCurve
+		Want  CubicCurve
+	}{
+		{
+			QuadraticCurve{{0, 0}, {3, 3}, {6, 0}},
+			CubicCurve{{0, 0}, {2, 2}, {4, 2}, {6, 0}},
+		},
+		{
+			QuadraticCurve{{6, 0}, {3, 3}, {0, 0}},
+			CubicCurve{{6, 0}, {4, 2}, {2, 2}, {0, 0}},
+		},
+		{
+			QuadraticCurve{{0, 0}, {0, 3}, {3, 3}},
+			CubicCurve{{0, 0}, {0, 2}, {1, 3}, {3, 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Curve), func(t *testing.T) {
+			got := test.Curve.CubicCurve()
+			for i := range got {
+				if math.Abs(got[i].X-test.Want[i].X) > 1e-12 || math.Abs(got[i].Y-test.Want[i].Y) > 1e-12 {
+					t.Errorf("wrong result %#v; want %#v", got, test.Want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestQuadraticCurveEval(t *testing.T) {
+	q := QuadraticCurve{{0, 0}, {2, 4}, {4, 0}}
+	c := q.CubicCurve()
+	const eps = 1e-12
+
+	for _, u := range []float64{0, 0.2, 0.5, 0.9, 1} {
+		t.Run(fmt.Sprintf("%g", u), func(t *testing.T) {
+			if got, want := q.At(u), c.At(u); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong point %#v; want %#v", got, want)
+			}
+			if got, want := q.Derivative(u), c.Derivative(u); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong derivative %#v; want %#v", got, want)
+			}
+			if got, want := q.SecondDerivative(u), c.SecondDerivative(u); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong second derivative %#v; want %#v", got, want)
+			}
+			if got, want := q.Normal(u), c.Normal(u); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong normal %#v; want %#v", got, want)
+			}
+			if got, want := q.Curvature(u), c.Curvature(u); math.Abs(got-want) > eps {
+				t.Errorf("wrong curvature %g; want %g", got, want)
+			}
+
+			a, b := q.Split(u)
+			if got, want := a.At(0.5), q.At(u/2); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong first part %#v; want %#v", got, want)
+			}
+			if got, want := b.At(0.5), q.At((1+u)/2); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong second part %#v; want %#v", got, want)
+			}
+			if got, want := q.SubCurve(1, u).At(0.5), q.At((1+u)/2); !got.ApproxEqual(want, eps) {
+				t.Errorf("wrong reversed sub-curve %#v; want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestCubicCurveFlatten(t *testing.T) {
+	curves := []CubicCurve{
+		{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+		{{0, 0}, {30, 10}, {-20, 10}, {10, 0}}, // self-intersecting loop
+		{{0, 0}, {0, 0}, {5, 5}, {5, 5}},
+		{{1, 1}, {1, 1}, {1, 1}, {1, 1}},
+		LineSeg{{0, 0}, {3, 4}}.CubicCurve(),
+	}
+
+	for _, c := range curves {
+		for _, tol := range []float64{1, 0.1, 0.001} {
+			t.Run(fmt.Sprintf("%#v %g", c, tol), func(t *testing.T) {
+				got := c.Flatten(tol)
+				if len(got) < 2 {
+					t.Fatalf("too few points %d", len(got))
+				}
+				if got[0] != c[0] || got[len(got)-1] != c[3] {
+					t.Errorf("wrong endpoints %#v and %#v", got[0], got[len(got)-1])
+				}
+				for i := 0; i <= 1000; i++ {
+					p := c.At(float64(i) / 1000)
+					best := math.Inf(1)
+					it := got.Iterator()
+					for it.Next() {
+						best = math.Min(best, segDist(it.LineSeg(), p))
+					}
+					if best > tol {
+						t.Fatalf("curve point %#v is %g from the result", p, best)
+					}
+				}
+			})
+		}
+	}
+
+	t.Run("straight", func(t *testing.T) {
+		got := LineSeg{{0, 0}, {3, 4}}.CubicCurve().Flatten(0.01)
+		want := LineSegSeq{{0, 0}, {3, 4}}
+		for _, problem := range deep.Equal(got, want) {
+			t.Error(problem)
+		}
+	})
+}
+
+func TestCubicCurveSeqFlatten(t *testing.T) {
+	seq := BeginCubicCurveSeq(Point{0, 0}, 2).
+		Append(Point{0, 10}, Point{10, 10}, Point{10, 0}).
+		Append(Point{40.0 / 3, 0}, Point{50.0 / 3, 0}, Point{20, 0})
+	got := seq.Flatten(0.5)
+
+	it := seq.Iterator()
+	it.Next()
+	want := it.CubicCurve().Flatten(0.5)
+	want = append(want, Point{20, 0})
+	for _, problem := range deep.Equal(got, want) {
+		t.Error(problem)
+	}
+
+	if got := CubicCurveSeq(nil).Flatten(1); got != nil {
+		t.Errorf("wrong result for empty sequence %#v; want nil", got)
+	}
+	for _, problem := range deep.Equal(CubicCurveSeq{{1, 2}}.Flatten(1), LineSegSeq{{1, 2}}) {
+		t.Error(problem)
+	}
+}
@@ -0,0 +1,84 @@
+package geom
+
+import (
+	"sort"
+
+	"github.com/apparentlymart/go-geometry/geom/robust"
+)
+
+// ConvexHull returns the smallest convex polygon that contains all of the
+// given points, using Andrew's monotone chain algorithm.
+//
+// The resulting polygon always has facing -1, and includes only the points
+// at its corners, omitting any that lie along its edges. If the given points
+// are all collinear then the result has only the two extreme points, or just
+// one point if all of the given points are equal, and is therefore not a
+// valid polygon. The result is nil if no points are given.
+func ConvexHull(pts []Point) Poly {
+	if len(pts) == 0 {
+		return nil
+	}
+
+	sorted := make([]Point, len(pts))
+	copy(sorted, pts)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Y < b.Y
+	})
+
+	hull := make(Poly, 0, len(sorted)+1)
+	for _, p := range sorted {
+		for len(hull) >= 2 && orient(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && orient(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	// The last point is always the same as the first.
+	hull = hull[:len(hull)-1]
+	if len(hull) == 2 && hull[0] == hull[1] {
+		hull = hull[:1]
+	}
+	return hull
+}
+
+// ConvexHull returns a convex polygon that contains all of the curves in the
+// receiving sequence, with the same properties as the result of the
+// ConvexHull function.
+//
+// Rather than just taking the hull of the control points, each curve is
+// split at its extrema and then subdivided until the control points of each
+// piece are within the given tolerance of the piece's chord. The result is
+// therefore no further than the tolerance from the true convex hull of the
+// curves, while still containing every point along them.
+//
+// The tolerance must be greater than zero.
+func (s CubicCurveSeq) ConvexHull(tolerance float64) Poly {
+	var pts []Point
+	for it := s.Iterator(); it.Next(); {
+		pts = it.CubicCurve().appendHullPoints(pts, tolerance)
+	}
+	if len(s) == 1 {
+		pts = append(pts, s[0])
+	}
+	return ConvexHull(pts)
+}
+
+// orient returns a positive value if the points a, b and c are in
+// anti-clockwise order, a negative value if they are in clockwise order, and
+// zero if they are collinear. The sign of the result is exact; see
+// robust.Orient2D.
+func orient(a, b, c Point) float64 {
+	return robust.Orient2D(a.X, a.Y, b.X, b.Y, c.X, c.Y)
+}
@@ -0,0 +1,70 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestConvexHull(t *testing.T) {
+	tests := []struct {
+		Points []Point
+		Want   Poly
+	}{
+		{
+			nil,
+			nil,
+		},
+		{
+			[]Point{{1, 2}, {1, 2}},
+			Poly{{1, 2}},
+		},
+		{
+			[]Point{{0, 0}, {2, 2}, {1, 1}},
+			Poly{{0, 0}, {2, 2}},
+		},
+		{
+			[]Point{{0, 0}, {0, 1}, {1, 0}},
+			Poly{{0, 0}, {1, 0}, {0, 1}},
+		},
+		{
+			// Clockwise input still produces an anti-clockwise result,
+			// and the interior and edge points are discarded.
+			[]Point{{0, 0}, {0, 4}, {2, 4}, {4, 4}, {4, 0}, {1, 1}, {3, 2}},
+			Poly{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Points), func(t *testing.T) {
+			got := ConvexHull(test.Points)
+
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestCubicCurveSeqConvexHull(t *testing.T) {
+	// A curve that bulges well beyond its endpoints but stays far inside
+	// its control points.
+	seq := BeginCubicCurveSeq(Point{0, 0}, 1).Append(Point{0, 10}, Point{10, 10}, Point{10, 0})
+	got := seq.ConvexHull(0.01)
+
+	if got.Facing() != -1 {
+		t.Errorf("wrong facing %d; want -1", got.Facing())
+	}
+
+	// The curve's highest point is at y=7.5, so the hull must reach at
+	// least that high but not much further.
+	maxY := math.Inf(-1)
+	for _, p := range got {
+		maxY = math.Max(maxY, p.Y)
+	}
+	if maxY < 7.5 || maxY > 7.51 {
+		t.Errorf("wrong maximum Y %f; want 7.5", maxY)
+	}
+}
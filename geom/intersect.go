@@ -0,0 +1,430 @@
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// Intersection describes a point where two shapes meet.
+type Intersection struct {
+	Point Point
+
+	// T is the parameter of the point along the receiver of the method
+	// that found the intersection, and U is its parameter along the
+	// method's argument. Both range from zero at the start of their shape
+	// to one at its end.
+	T, U float64
+}
+
+// IntersectLineSeg returns the points where the receiver meets the given
+// other line segment, in order of increasing T.
+//
+// There is one intersection if the segments cross or touch. If they are
+// collinear and overlap then there are instead intersections at each end
+// of the overlapping part, or just one if the overlap is a single point.
+func (s LineSeg) IntersectLineSeg(o LineSeg) []Intersection {
+	switch {
+	case s[0] == s[1] && o[0] == o[1]:
+		if s[0] != o[0] {
+			return nil
+		}
+		return []Intersection{{s[0], 0, 0}}
+	case o[0] == o[1]:
+		if !Rect(s).Contains(o[0]) || orient(s[0], s[1], o[0]) != 0 {
+			return nil
+		}
+		return []Intersection{{o[0], s.param(o[0]), 0}}
+	case s[0] == s[1]:
+		return swapIntersections(o.IntersectLineSeg(s))
+	}
+
+	if x, ok := crossing(s, o); ok {
+		return []Intersection{{x, s.param(x), o.param(x)}}
+	}
+	if orient(s[0], s[1], o[0]) != 0 || orient(s[0], s[1], o[1]) != 0 {
+		return nil
+	}
+
+	// The segments are collinear, so the overlap is the range of T values
+	// that the ends of o project to, limited to the ends of s.
+	t0, t1 := s.param(o[0]), s.param(o[1])
+	a, b := o[0], o[1]
+	if t0 > t1 {
+		t0, t1 = t1, t0
+		a, b = b, a
+	}
+	if t1 < 0 || t0 > 1 {
+		return nil
+	}
+	if t0 < 0 {
+		t0, a = 0, s[0]
+	}
+	if t1 > 1 {
+		t1, b = 1, s[1]
+	}
+	ret := []Intersection{{a, t0, o.param(a)}}
+	if a != b {
+		ret = append(ret, Intersection{b, t1, o.param(b)})
+	}
+	return ret
+}
+
+// IntersectCubicCurve returns the points where the receiver meets the
+// given curve, in order of increasing T.
+//
+// If part of the curve lies along the segment then the ends of each
+// overlapping part are reported. A segment of zero length has an
+// intersection only if the curve passes exactly through its point.
+func (s LineSeg) IntersectCubicCurve(c CubicCurve) []Intersection {
+	var ts []float64
+	if s[0] == s[1] {
+		// We find where the curve crosses the horizontal line through the
+		// point, and then check which of those are at the point itself.
+		ts = cubicRootsIn(c, func(p Point) float64 { return p.Y - s[0].Y })
+		ts = append(ts, cubicRootsIn(c, func(p Point) float64 { return p.X - s[0].X })...)
+	} else {
+		d := s[1].Sub(s[0])
+		dist := func(p Point) float64 { return d.Cross(p.Sub(s[0])) }
+		if dist(c[0]) == 0 && dist(c[1]) == 0 && dist(c[2]) == 0 && dist(c[3]) == 0 {
+			// The curve lies along the line through the segment, so the
+			// overlapping parts end where the curve passes through the ends
+			// of the segment or at the ends of the curve.
+			ts = cubicRootsIn(c, func(p Point) float64 { return d.Dot(p.Sub(s[0])) })
+			ts = append(ts, cubicRootsIn(c, func(p Point) float64 { return d.Dot(p.Sub(s[1])) })...)
+			ts = append(ts, 0, 1)
+		} else {
+			ts = cubicRootsIn(c, dist)
+		}
+	}
+
+	var ret []Intersection
+	for _, t := range ts {
+		p := c.At(t)
+		var u float64
+		if s[0] == s[1] {
+			if !p.ApproxEqual(s[0], intersectEpsilon*scaleOf(s[0], c)) {
+				continue
+			}
+			p = s[0]
+		} else {
+			u = s.param(p)
+			if u < -intersectEpsilon || u > 1+intersectEpsilon {
+				continue
+			}
+			switch {
+			case u <= 0:
+				u = 0
+			case u >= 1:
+				u = 1
+			}
+		}
+		ret = append(ret, Intersection{p, u, t})
+	}
+	return dedupeIntersections(ret)
+}
+
+// IntersectLineSeg returns the points where the receiver meets the given
+// line segment, in order of increasing T, as described for
+// LineSeg.IntersectCubicCurve.
+func (c CubicCurve) IntersectLineSeg(s LineSeg) []Intersection {
+	return swapIntersections(s.IntersectCubicCurve(c))
+}
+
+// IntersectCubicCurve returns the points where the receiver meets the
+// given other curve, in order of increasing T.
+//
+// If the curves coincide along part of their length then, as for
+// LineSeg.IntersectLineSeg, there are instead intersections at each end of
+// the overlapping part.
+//
+// Otherwise, the intersections are found by recursively subdividing both
+// curves, discarding pairs of pieces whose bounding rectangles don't
+// overlap, until the pieces are flat enough to treat as line segments. The
+// results are then refined using Newton's method.
+func (c CubicCurve) IntersectCubicCurve(o CubicCurve) []Intersection {
+	scale := scaleOf(c[0], c)
+	for _, p := range o {
+		scale = math.Max(scale, math.Max(math.Abs(p.X), math.Abs(p.Y)))
+	}
+	if ret := cubicOverlap(c, o, overlapEpsilon*math.Max(scale, 1)); ret != nil {
+		return ret
+	}
+	x := curveIntersector{
+		a:   c,
+		b:   o,
+		tol: intersectEpsilon * math.Max(scale, 1),
+	}
+	x.find(c, 0, 1, o, 0, 1, 0)
+	return dedupeIntersections(x.found)
+}
+
+// cubicOverlap returns intersections at the ends of the part where the two
+// given curves coincide, or nil if they don't coincide along any part of
+// their length, allowing for the given distance between them.
+//
+// Where two curves coincide, the ends of the overlapping part must each be
+// an end of one curve or the other. We therefore find which of the ends lie
+// on both curves, and compare the pieces of the curves between each pair of
+// them.
+func cubicOverlap(a, b CubicCurve, tol float64) []Intersection {
+	var ends []Intersection
+	for _, t := range []float64{0, 1} {
+		p := a.At(t)
+		if n := b.Nearest(p); n.Dist <= tol {
+			ends = append(ends, Intersection{p, t, n.T})
+		}
+	}
+	for _, u := range []float64{0, 1} {
+		p := b.At(u)
+		if n := a.Nearest(p); n.Dist <= tol {
+			ends = append(ends, Intersection{p, n.T, u})
+		}
+	}
+
+	for i, e0 := range ends {
+		for _, e1 := range ends[i+1:] {
+			if e0.Point.ApproxEqual(e1.Point, tol) {
+				continue
+			}
+			pa, pb := a.SubCurve(e0.T, e1.T), b.SubCurve(e0.U, e1.U)
+			same := true
+			for k := range pa {
+				same = same && pa[k].ApproxEqual(pb[k], tol)
+			}
+			if !same {
+				continue
+			}
+			if e1.T < e0.T {
+				e0, e1 = e1, e0
+			}
+			return []Intersection{e0, e1}
+		}
+	}
+	return nil
+}
+
+// overlapEpsilon is the relative tolerance used when deciding whether two
+// curves coincide. It is larger than intersectEpsilon because the pieces
+// being compared are found using approximate parameters.
+const overlapEpsilon = 1e-7
+
+// intersectEpsilon is the relative tolerance used when finding
+// intersections involving curves, which can be found only approximately.
+const intersectEpsilon = 1e-9
+
+type curveIntersector struct {
+	a, b  CubicCurve
+	tol   float64
+	found []Intersection
+}
+
+// find adds to x.found the intersections between the given pieces of the
+// two curves, which cover the given parameter ranges of the whole curves.
+func (x *curveIntersector) find(a CubicCurve, at0, at1 float64, b CubicCurve, bt0, bt1 float64, depth int) {
+	if !a.hullBounds().Outset(x.tol).Overlaps(b.hullBounds()) {
+		return
+	}
+	aFlat, bFlat := a.flat(x.tol), b.flat(x.tol)
+	if (aFlat && bFlat) || depth >= 64 {
+		for _, hit := range (LineSeg{a[0], a[3]}).IntersectLineSeg(LineSeg{b[0], b[3]}) {
+			t := at0 + hit.T*(at1-at0)
+			u := bt0 + hit.U*(bt1-bt0)
+			x.found = append(x.found, x.refine(t, u))
+		}
+		return
+	}
+
+	// We split whichever piece is less flat, or both if neither is.
+	switch {
+	case aFlat:
+		b0, b1 := b.Split(0.5)
+		bm := (bt0 + bt1) / 2
+		x.find(a, at0, at1, b0, bt0, bm, depth+1)
+		x.find(a, at0, at1, b1, bm, bt1, depth+1)
+	case bFlat:
+		a0, a1 := a.Split(0.5)
+		am := (at0 + at1) / 2
+		x.find(a0, at0, am, b, bt0, bt1, depth+1)
+		x.find(a1, am, at1, b, bt0, bt1, depth+1)
+	default:
+		a0, a1 := a.Split(0.5)
+		b0, b1 := b.Split(0.5)
+		am, bm := (at0+at1)/2, (bt0+bt1)/2
+		x.find(a0, at0, am, b0, bt0, bm, depth+1)
+		x.find(a0, at0, am, b1, bm, bt1, depth+1)
+		x.find(a1, am, at1, b0, bt0, bm, depth+1)
+		x.find(a1, am, at1, b1, bm, bt1, depth+1)
+	}
+}
+
+// refine improves an approximate intersection at parameters t and u using
+// Newton's method, keeping the original if the iteration doesn't converge.
+func (x *curveIntersector) refine(t, u float64) Intersection {
+	best := Intersection{x.a.At(t), t, u}
+	bestDist := best.Point.Dist(x.b.At(u))
+	for i := 0; i < 8 && bestDist > 0; i++ {
+		// Solve a(t) - b(u) = 0 for the step in t and u.
+		f := x.a.At(t).Sub(x.b.At(u))
+		da, db := x.a.Derivative(t), x.b.Derivative(u).Scale(-1)
+		det := da.Cross(db)
+		if det == 0 {
+			break
+		}
+		t -= f.Cross(db) / det
+		u -= da.Cross(f) / det
+		if t < 0 || t > 1 || u < 0 || u > 1 {
+			break
+		}
+		p := x.a.At(t)
+		if dist := p.Dist(x.b.At(u)); dist < bestDist {
+			best, bestDist = Intersection{p, t, u}, dist
+		} else {
+			break
+		}
+	}
+	return best
+}
+
+// hullBounds returns the rectangle containing the control points of the
+// curve, which is quicker to find than the exact bounds returned by method
+// Bounds.
+func (c CubicCurve) hullBounds() Rect {
+	return BoundingRect(c[:])
+}
+
+// param returns the parameter of the point on the segment closest to p,
+// which is outside of the range zero to one if that point is beyond the
+// ends of the segment.
+func (s LineSeg) param(p Point) float64 {
+	switch p {
+	case s[0]:
+		return 0
+	case s[1]:
+		return 1
+	}
+	d := s[1].Sub(s[0])
+	return p.Sub(s[0]).Dot(d) / d.LenSq()
+}
+
+// cubicRootsIn returns the parameters in the range zero to one where the
+// given linear function of position is zero along the curve.
+func cubicRootsIn(c CubicCurve, f func(Point) float64) []float64 {
+	d0, d1, d2, d3 := f(c[0]), f(c[1]), f(c[2]), f(c[3])
+	a := -d0 + 3*d1 - 3*d2 + d3
+	b := 3*d0 - 6*d1 + 3*d2
+	cc := -3*d0 + 3*d1
+	d := d0
+
+	var ret []float64
+	for _, t := range cubicRoots(a, b, cc, d) {
+		if t < -intersectEpsilon || t > 1+intersectEpsilon {
+			continue
+		}
+		ret = append(ret, math.Max(0, math.Min(1, t)))
+	}
+	if d0 == 0 {
+		ret = append(ret, 0)
+	}
+	if d3 == 0 {
+		ret = append(ret, 1)
+	}
+	return ret
+}
+
+// cubicRoots returns the real roots of the polynomial
+// a*t*t*t + b*t*t + c*t + d, which may be of lower degree if a is zero or
+// very small compared to the other coefficients.
+func cubicRoots(a, b, c, d float64) []float64 {
+	if math.Abs(a) <= 1e-12*math.Max(math.Abs(b), math.Max(math.Abs(c), math.Abs(d))) {
+		return quadraticRoots(b, c, d)
+	}
+
+	// This is the trigonometric method for three real roots and Cardano's
+	// method for one, as presented in Numerical Recipes.
+	b, c, d = b/a, c/a, d/a
+	q := (b*b - 3*c) / 9
+	r := (2*b*b*b - 9*b*c + 27*d) / 54
+	var ret []float64
+	if r*r < q*q*q {
+		theta := math.Acos(r / math.Sqrt(q*q*q))
+		m := -2 * math.Sqrt(q)
+		ret = []float64{
+			m*math.Cos(theta/3) - b/3,
+			m*math.Cos((theta+2*math.Pi)/3) - b/3,
+			m*math.Cos((theta-2*math.Pi)/3) - b/3,
+		}
+	} else {
+		e := -math.Copysign(math.Cbrt(math.Abs(r)+math.Sqrt(r*r-q*q*q)), r)
+		f := 0.0
+		if e != 0 {
+			f = q / e
+		}
+		ret = []float64{e + f - b/3}
+	}
+
+	// A few steps of Newton's method correct for rounding errors in the
+	// closed-form solution.
+	for i, t := range ret {
+		for j := 0; j < 4; j++ {
+			v := ((t+b)*t+c)*t + d
+			dv := (3*t+2*b)*t + c
+			if dv == 0 {
+				break
+			}
+			t -= v / dv
+		}
+		ret[i] = t
+	}
+	return ret
+}
+
+// scaleOf returns the largest absolute coordinate of the given point and
+// curve, which is used to scale tolerances to the magnitude of the input.
+func scaleOf(p Point, c CubicCurve) float64 {
+	ret := math.Max(math.Abs(p.X), math.Abs(p.Y))
+	for _, q := range c {
+		ret = math.Max(ret, math.Max(math.Abs(q.X), math.Abs(q.Y)))
+	}
+	return ret
+}
+
+// dedupeIntersections sorts the given intersections by T and removes those
+// that are very close to another in both parameters, which can arise from
+// approximate methods finding the same intersection more than once.
+func dedupeIntersections(xs []Intersection) []Intersection {
+	if len(xs) == 0 {
+		return nil
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		if xs[i].T != xs[j].T {
+			return xs[i].T < xs[j].T
+		}
+		return xs[i].U < xs[j].U
+	})
+	ret := xs[:1]
+	for _, x := range xs[1:] {
+		dup := false
+		for i := len(ret) - 1; i >= 0 && x.T-ret[i].T <= 1e-7; i-- {
+			if math.Abs(x.U-ret[i].U) <= 1e-7 {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			ret = append(ret, x)
+		}
+	}
+	return ret
+}
+
+// swapIntersections exchanges the T and U parameters of the given
+// intersections, and sorts them by their new T values.
+func swapIntersections(xs []Intersection) []Intersection {
+	for i := range xs {
+		xs[i].T, xs[i].U = xs[i].U, xs[i].T
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].T < xs[j].T
+	})
+	return xs
+}
@@ -0,0 +1,228 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestLineSegIntersectLineSeg(t *testing.T) {
+	tests := []struct {
+		A, B LineSeg
+		Want []Intersection
+	}{
+		{
+			LineSeg{{0, 0}, {4, 4}},
+			LineSeg{{0, 4}, {4, 0}},
+			[]Intersection{{Point{2, 2}, 0.5, 0.5}},
+		},
+		{
+			LineSeg{{0, 0}, {4, 0}},
+			LineSeg{{1, 0}, {1, 3}},
+			[]Intersection{{Point{1, 0}, 0.25, 0}},
+		},
+		{
+			LineSeg{{0, 0}, {4, 0}},
+			LineSeg{{1, 1}, {1, 3}},
+			nil,
+		},
+		{
+			LineSeg{{0, 0}, {4, 0}},
+			LineSeg{{0, 1}, {4, 1}},
+			nil,
+		},
+		{
+			// collinear overlap, in opposite directions
+			LineSeg{{0, 0}, {4, 0}},
+			LineSeg{{6, 0}, {2, 0}},
+			[]Intersection{
+				{Point{2, 0}, 0.5, 1},
+				{Point{4, 0}, 1, 0.5},
+			},
+		},
+		{
+			// collinear, meeting at a single point
+			LineSeg{{0, 0}, {4, 0}},
+			LineSeg{{4, 0}, {8, 0}},
+			[]Intersection{{Point{4, 0}, 1, 0}},
+		},
+		{
+			// collinear, with no overlap
+			LineSeg{{0, 0}, {4, 0}},
+			LineSeg{{5, 0}, {8, 0}},
+			nil,
+		},
+		{
+			LineSeg{{2, 2}, {2, 2}},
+			LineSeg{{0, 0}, {4, 4}},
+			[]Intersection{{Point{2, 2}, 0, 0.5}},
+		},
+		{
+			LineSeg{{2, 2}, {2, 2}},
+			LineSeg{{0, 0}, {4, 0}},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v", test.A, test.B), func(t *testing.T) {
+			got := test.A.IntersectLineSeg(test.B)
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestLineSegIntersectCubicCurve(t *testing.T) {
+	arch := CubicCurve{{0, 0}, {0, 10}, {10, 10}, {10, 0}}
+
+	tests := []struct {
+		Seg  LineSeg
+		Want []Intersection
+	}{
+		{
+			LineSeg{{0, 5.625}, {10, 5.625}},
+			[]Intersection{
+				{Point{1.5625, 5.625}, 0.15625, 0.25},
+				{Point{8.4375, 5.625}, 0.84375, 0.75},
+			},
+		},
+		{
+			// reaching only as far as the first crossing
+			LineSeg{{1.5625, 5.625}, {-10, 5.625}},
+			[]Intersection{
+				{Point{1.5625, 5.625}, 0, 0.25},
+			},
+		},
+		{
+			// touching the top of the arch
+			LineSeg{{0, 7.5}, {10, 7.5}},
+			[]Intersection{
+				{Point{5, 7.5}, 0.5, 0.5},
+			},
+		},
+		{
+			LineSeg{{0, 8}, {10, 8}},
+			nil,
+		},
+		{
+			LineSeg{{-5, 0}, {15, 0}},
+			[]Intersection{
+				{Point{0, 0}, 0.25, 0},
+				{Point{10, 0}, 0.75, 1},
+			},
+		},
+		{
+			LineSeg{{5, 7.5}, {5, 7.5}},
+			[]Intersection{
+				{Point{5, 7.5}, 0, 0.5},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Seg), func(t *testing.T) {
+			got := test.Seg.IntersectCubicCurve(arch)
+			if len(got) != len(test.Want) {
+				t.Fatalf("wrong result %#v; want %#v", got, test.Want)
+			}
+			for i, want := range test.Want {
+				g := got[i]
+				if !g.Point.ApproxEqual(want.Point, 1e-6) || math.Abs(g.T-want.T) > 1e-6 || math.Abs(g.U-want.U) > 1e-6 {
+					t.Errorf("wrong intersection %d %#v; want %#v", i, g, want)
+				}
+			}
+
+			swapped := arch.IntersectLineSeg(test.Seg)
+			for i := range swapped {
+				if swapped[i].T != got[i].U || swapped[i].U != got[i].T {
+					t.Errorf("wrong swapped intersection %d %#v", i, swapped[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCubicCurveIntersectCubicCurve(t *testing.T) {
+	arch := CubicCurve{{0, 0}, {0, 10}, {10, 10}, {10, 0}}
+	bowl := CubicCurve{{0, 7.5}, {0, -2.5}, {10, -2.5}, {10, 7.5}}
+
+	got := arch.IntersectCubicCurve(bowl)
+	if len(got) != 2 {
+		t.Fatalf("wrong result %#v; want two intersections", got)
+	}
+	for i, want := range []float64{(1 - math.Sqrt(0.5)) / 2, (1 + math.Sqrt(0.5)) / 2} {
+		if math.Abs(got[i].T-want) > 1e-9 || math.Abs(got[i].U-want) > 1e-9 {
+			t.Errorf("wrong parameters for intersection %d %#v; want %g", i, got[i], want)
+		}
+		if !got[i].Point.ApproxEqual(arch.At(want), 1e-9) {
+			t.Errorf("wrong point for intersection %d %#v; want %#v", i, got[i].Point, arch.At(want))
+		}
+	}
+
+	far := CubicCurve{{20, 0}, {20, 10}, {30, 10}, {30, 0}}
+	if got := arch.IntersectCubicCurve(far); len(got) != 0 {
+		t.Errorf("unexpected intersections %#v", got)
+	}
+
+	a, b := arch.Split(0.5)
+	got = a.IntersectCubicCurve(b)
+	want := []Intersection{{Point{5, 7.5}, 1, 0}}
+	for _, problem := range deep.Equal(got, want) {
+		t.Error(problem)
+	}
+}
+
+func TestCubicCurveIntersectCubicCurveOverlap(t *testing.T) {
+	c := CubicCurve{{0, 0}, {1, 2}, {3, 2}, {4, 0}}
+
+	tests := []struct {
+		Name  string
+		Other CubicCurve
+		Want  []Intersection
+	}{
+		{
+			"identical",
+			c,
+			[]Intersection{{c[0], 0, 0}, {c[3], 1, 1}},
+		},
+		{
+			"reversed",
+			CubicCurve{c[3], c[2], c[1], c[0]},
+			[]Intersection{{c[0], 0, 1}, {c[3], 1, 0}},
+		},
+		{
+			"contained",
+			c.SubCurve(0.25, 0.75),
+			[]Intersection{{c.At(0.25), 0.25, 0}, {c.At(0.75), 0.75, 1}},
+		},
+		{
+			"partial",
+			c.SubCurve(0.5, 2),
+			[]Intersection{{c.At(0.5), 0.5, 0}, {c[3], 1, 1.0 / 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := c.IntersectCubicCurve(test.Other)
+			if len(got) != len(test.Want) {
+				t.Fatalf("wrong result %#v; want %#v", got, test.Want)
+			}
+			for i, want := range test.Want {
+				g := got[i]
+				if !g.Point.ApproxEqual(want.Point, 1e-9) || math.Abs(g.T-want.T) > 1e-6 || math.Abs(g.U-want.U) > 1e-6 {
+					t.Errorf("wrong intersection %d %#v; want %#v", i, g, want)
+				}
+			}
+
+			// The overlap is the same from the other curve's perspective.
+			if got := test.Other.IntersectCubicCurve(c); len(got) != len(test.Want) {
+				t.Errorf("wrong reverse result %#v", got)
+			}
+		})
+	}
+}
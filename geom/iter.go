@@ -0,0 +1,82 @@
+package geom
+
+import (
+	"iter"
+)
+
+// SeqFromLineSegIterator adapts a LineSegIterator for use with a range
+// statement.
+//
+// Because the underlying iterator is stateful, the result can be used
+// only once. Methods such as LineSegSeq.Segments return sequences that can
+// be used any number of times.
+func SeqFromLineSegIterator(it LineSegIterator) iter.Seq[LineSeg] {
+	return func(yield func(LineSeg) bool) {
+		for it.Next() {
+			if !yield(it.LineSeg()) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFromCubicCurveIterator adapts a CubicCurveIterator for use with a
+// range statement. As with SeqFromLineSegIterator, the result can be used
+// only once.
+func SeqFromCubicCurveIterator(it CubicCurveIterator) iter.Seq[CubicCurve] {
+	return func(yield func(CubicCurve) bool) {
+		for it.Next() {
+			if !yield(it.CubicCurve()) {
+				return
+			}
+		}
+	}
+}
+
+// PullLineSegs adapts a sequence of line segments to the LineSegIterator
+// interface, using iter.Pull.
+//
+// As with iter.Pull, the caller must call the returned stop function if it
+// doesn't continue calling Next until it returns false, to release the
+// resources associated with the sequence.
+func PullLineSegs(seq iter.Seq[LineSeg]) (LineSegIterator, func()) {
+	next, stop := iter.Pull(seq)
+	return &pullLineSegIter{next: next}, stop
+}
+
+// PullCubicCurves adapts a sequence of curves to the CubicCurveIterator
+// interface, with the same requirements as PullLineSegs.
+func PullCubicCurves(seq iter.Seq[CubicCurve]) (CubicCurveIterator, func()) {
+	next, stop := iter.Pull(seq)
+	return &pullCubicCurveIter{next: next}, stop
+}
+
+type pullLineSegIter struct {
+	next func() (LineSeg, bool)
+	cur  LineSeg
+}
+
+func (i *pullLineSegIter) Next() bool {
+	var ok bool
+	i.cur, ok = i.next()
+	return ok
+}
+
+func (i *pullLineSegIter) LineSeg() LineSeg {
+	return i.cur
+}
+
+type pullCubicCurveIter struct {
+	next func() (CubicCurve, bool)
+	cur  CubicCurve
+}
+
+func (i *pullCubicCurveIter) Next() bool {
+	var ok bool
+	i.cur, ok = i.next()
+	return ok
+}
+
+func (i *pullCubicCurveIter) CubicCurve() CubicCurve {
+	return i.cur
+}
@@ -0,0 +1,94 @@
+package geom
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSeqMethods(t *testing.T) {
+	t.Run("LineSegSeq", func(t *testing.T) {
+		s := LineSegSeq{{0, 0}, {1, 0}, {1, 1}}
+		want := []LineSeg{{{0, 0}, {1, 0}}, {{1, 0}, {1, 1}}}
+		for _, problem := range deep.Equal(slices.Collect(s.Segments()), want) {
+			t.Error(problem)
+		}
+		// The sequence can be used more than once.
+		for _, problem := range deep.Equal(slices.Collect(s.Segments()), want) {
+			t.Error(problem)
+		}
+		var idx []int
+		for i, v := range s.Vertices() {
+			if v != s[i] {
+				t.Errorf("wrong vertex %d %#v; want %#v", i, v, s[i])
+			}
+			idx = append(idx, i)
+		}
+		for _, problem := range deep.Equal(idx, []int{0, 1, 2}) {
+			t.Error(problem)
+		}
+	})
+	t.Run("Poly", func(t *testing.T) {
+		p := Poly{{0, 0}, {1, 0}, {1, 1}}
+		want := []LineSeg{{{0, 0}, {1, 0}}, {{1, 0}, {1, 1}}, {{1, 1}, {0, 0}}}
+		for _, problem := range deep.Equal(slices.Collect(p.Segments()), want) {
+			t.Error(problem)
+		}
+		var got []LineSeg
+		for s := range p.Segments() {
+			got = append(got, s)
+			break
+		}
+		for _, problem := range deep.Equal(got, want[:1]) {
+			t.Error(problem)
+		}
+	})
+	t.Run("CubicCurveSeq", func(t *testing.T) {
+		s := BeginCubicCurveSeq(Point{0, 0}, 2).
+			Append(Point{0, 1}, Point{1, 1}, Point{1, 0}).
+			Append(Point{1, -1}, Point{2, -1}, Point{2, 0})
+		want := []CubicCurve{
+			{{0, 0}, {0, 1}, {1, 1}, {1, 0}},
+			{{1, 0}, {1, -1}, {2, -1}, {2, 0}},
+		}
+		for _, problem := range deep.Equal(slices.Collect(s.Curves()), want) {
+			t.Error(problem)
+		}
+	})
+}
+
+func TestIteratorAdapters(t *testing.T) {
+	segs := LineSegSeq{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	want := slices.Collect(segs.Segments())
+
+	got := slices.Collect(SeqFromLineSegIterator(segs.Iterator()))
+	for _, problem := range deep.Equal(got, want) {
+		t.Error(problem)
+	}
+
+	it, stop := PullLineSegs(segs.Segments())
+	defer stop()
+	got = nil
+	for it.Next() {
+		got = append(got, it.LineSeg())
+	}
+	for _, problem := range deep.Equal(got, want) {
+		t.Error(problem)
+	}
+
+	curves := CubicCurveSeq{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	wantCurves := []CubicCurve{{{0, 0}, {0, 1}, {1, 1}, {1, 0}}}
+	for _, problem := range deep.Equal(slices.Collect(SeqFromCubicCurveIterator(curves.Iterator())), wantCurves) {
+		t.Error(problem)
+	}
+	cit, stop := PullCubicCurves(curves.Curves())
+	defer stop()
+	var gotCurves []CubicCurve
+	for cit.Next() {
+		gotCurves = append(gotCurves, cit.CubicCurve())
+	}
+	for _, problem := range deep.Equal(gotCurves, wantCurves) {
+		t.Error(problem)
+	}
+}
@@ -19,6 +19,12 @@ func (c LineSeg) CubicCurve() CubicCurve {
 	}
 }
 
+// Bounds returns the smallest normalized rectangle containing the line
+// segment.
+func (s LineSeg) Bounds() Rect {
+	return Rect(s).Normalize()
+}
+
 // A LineSegger can convert itself into a line segment.
 type LineSegger interface {
 	LineSeg() LineSeg
@@ -1,5 +1,10 @@
 package geom
 
+import (
+	"iter"
+	"slices"
+)
+
 // LineSegSeq represents a sequence of connected line segments.
 //
 // The internal representation is a slice of points.
@@ -25,6 +30,30 @@ func (s LineSegSeq) Append(next Point) LineSegSeq {
 	return append(s, next)
 }
 
+// Bounds returns the smallest normalized rectangle containing all of the
+// line segments in the sequence.
+func (s LineSegSeq) Bounds() Rect {
+	return BoundingRect(s)
+}
+
+// Distinct returns the points of the receiver with consecutive duplicates
+// removed. If closed is true then the sequence is treated as returning to
+// its start point, and so a final point equal to the first is also removed.
+//
+// The result doesn't share a backing array with the receiver.
+func (s LineSegSeq) Distinct(closed bool) LineSegSeq {
+	ret := make(LineSegSeq, 0, len(s))
+	for _, p := range s {
+		if len(ret) == 0 || p != ret[len(ret)-1] {
+			ret = append(ret, p)
+		}
+	}
+	if closed && len(ret) > 1 && ret[len(ret)-1] == ret[0] {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
 // Iterator returns an interator over the line segments in the receiving
 // sequence.
 func (s LineSegSeq) Iterator() LineSegIterator {
@@ -35,6 +64,25 @@ func (s LineSegSeq) Iterator() LineSegIterator {
 	}
 }
 
+// Segments returns an iterator over the line segments in the receiving
+// sequence, for use with a range statement.
+func (s LineSegSeq) Segments() iter.Seq[LineSeg] {
+	return func(yield func(LineSeg) bool) {
+		it := s.Iterator()
+		for it.Next() {
+			if !yield(it.LineSeg()) {
+				return
+			}
+		}
+	}
+}
+
+// Vertices returns an iterator over the points in the receiving sequence
+// and their indices, for use with a range statement.
+func (s LineSegSeq) Vertices() iter.Seq2[int, Point] {
+	return slices.All(s)
+}
+
 // LineSegIterator is a stateful iterator over a sequence of line segments.
 type LineSegIterator interface {
 	Next() bool
@@ -50,10 +98,10 @@ type lineSegSeqIter struct {
 func (i *lineSegSeqIter) Next() bool {
 	i.pos++
 	if i.closed {
-		// one more iteration, then
-		return i.pos <= len(i.seq)
+		// one more iteration, to return to the first point
+		return i.pos < len(i.seq)
 	}
-	return i.pos <= (len(i.seq) - 1)
+	return i.pos < (len(i.seq) - 1)
 }
 
 func (i *lineSegSeqIter) LineSeg() LineSeg {
@@ -0,0 +1,93 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestLineSegSeqIterator(t *testing.T) {
+	tests := []struct {
+		Seq  LineSegSeq
+		Want []LineSeg
+	}{
+		{nil, nil},
+		{LineSegSeq{{0, 0}}, nil},
+		{LineSegSeq{{0, 0}, {1, 0}}, []LineSeg{{{0, 0}, {1, 0}}}},
+		{
+			LineSegSeq{{0, 0}, {1, 0}, {1, 1}},
+			[]LineSeg{{{0, 0}, {1, 0}}, {{1, 0}, {1, 1}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Seq), func(t *testing.T) {
+			got := collectLineSegs(test.Seq.Iterator())
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestLineSegSeqDistinct(t *testing.T) {
+	tests := []struct {
+		Seq    LineSegSeq
+		Closed bool
+		Want   LineSegSeq
+	}{
+		{nil, false, LineSegSeq{}},
+		{LineSegSeq{{0, 0}, {0, 0}}, true, LineSegSeq{{0, 0}}},
+		{
+			LineSegSeq{{0, 0}, {1, 0}, {1, 0}, {1, 1}, {0, 0}},
+			false,
+			LineSegSeq{{0, 0}, {1, 0}, {1, 1}, {0, 0}},
+		},
+		{
+			LineSegSeq{{0, 0}, {1, 0}, {1, 0}, {1, 1}, {0, 0}},
+			true,
+			LineSegSeq{{0, 0}, {1, 0}, {1, 1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v", test.Seq, test.Closed), func(t *testing.T) {
+			got := test.Seq.Distinct(test.Closed)
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestPolyIterator(t *testing.T) {
+	tests := []struct {
+		Poly Poly
+		Want []LineSeg
+	}{
+		{nil, nil},
+		{Poly{{0, 0}, {1, 0}}, []LineSeg{{{0, 0}, {1, 0}}, {{1, 0}, {0, 0}}}},
+		{
+			Poly{{0, 0}, {1, 0}, {1, 1}},
+			[]LineSeg{{{0, 0}, {1, 0}}, {{1, 0}, {1, 1}}, {{1, 1}, {0, 0}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Poly), func(t *testing.T) {
+			got := collectLineSegs(test.Poly.Iterator())
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func collectLineSegs(it LineSegIterator) []LineSeg {
+	var ret []LineSeg
+	for it.Next() {
+		ret = append(ret, it.LineSeg())
+	}
+	return ret
+}
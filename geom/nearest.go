@@ -0,0 +1,196 @@
+package geom
+
+import (
+	"math"
+)
+
+// NearestPoint describes the point on a shape that is closest to some other
+// point.
+type NearestPoint struct {
+	Point Point
+
+	// T is the parameter of the point along the shape. For a single
+	// segment or curve this ranges from zero at its start to one at its
+	// end. For a sequence of segments or curves, or the boundary of a
+	// polygon, the integer part is the index of the segment or curve and
+	// the fractional part is the parameter along it.
+	T float64
+
+	// Dist is the distance from the point to the other point.
+	Dist float64
+}
+
+// Nearest returns the point on the line segment that is closest to the
+// given point.
+func (s LineSeg) Nearest(p Point) NearestPoint {
+	t := 0.0
+	if s[0] != s[1] {
+		t = math.Max(0, math.Min(1, s.param(p)))
+	}
+	var q Point
+	switch t {
+	case 0:
+		q = s[0]
+	case 1:
+		q = s[1]
+	default:
+		q = s[0].Lerp(s[1], t)
+	}
+	return NearestPoint{q, t, q.Dist(p)}
+}
+
+// Nearest returns the point on the curve that is closest to the given
+// point. If there are several equally close points then the one with the
+// smallest parameter is returned.
+//
+// The search recursively subdivides the curve, discarding pieces whose
+// control points are all further away than the closest point found so far,
+// and then refines the result using Newton's method.
+func (c CubicCurve) Nearest(p Point) NearestPoint {
+	// The pieces need only be flat enough for the closest point on their
+	// chord to be a good starting point for refinement.
+	n := curveNearest{
+		c:   c,
+		p:   p,
+		tol: 1e-6 * math.Max(scaleOf(p, c), 1),
+	}
+	n.best = NearestPoint{c[0], 0, c[0].Dist(p)}
+	if d := c[3].Dist(p); d < n.best.Dist {
+		n.best = NearestPoint{c[3], 1, d}
+	}
+	n.search(c, 0, 1, 0)
+	return n.best
+}
+
+// Nearest returns the point on the curve that is closest to the given
+// point, as described for CubicCurve.Nearest.
+func (c QuadraticCurve) Nearest(p Point) NearestPoint {
+	// Raising the degree of a curve doesn't change its parameterization,
+	// so the parameter along the cubic is also the parameter along the
+	// receiver.
+	return c.CubicCurve().Nearest(p)
+}
+
+// Nearest returns the point on the sequence of line segments that is
+// closest to the given point. If there are several equally close points
+// then the one with the smallest parameter is returned.
+//
+// A sequence with only one point has that point as its nearest. If the
+// sequence is empty then the distance in the result is positive infinity.
+func (s LineSegSeq) Nearest(p Point) NearestPoint {
+	switch len(s) {
+	case 0:
+		return NearestPoint{Dist: math.Inf(1)}
+	case 1:
+		return NearestPoint{s[0], 0, s[0].Dist(p)}
+	}
+	return nearestAlong(len(s)-1, p, func(i int) NearestPoint {
+		return LineSeg{s[i], s[i+1]}.Nearest(p)
+	})
+}
+
+// Nearest returns the point on the sequence of curves that is closest to
+// the given point, with the same conventions as LineSegSeq.Nearest.
+func (s CubicCurveSeq) Nearest(p Point) NearestPoint {
+	switch {
+	case len(s) == 0:
+		return NearestPoint{Dist: math.Inf(1)}
+	case len(s) < 4:
+		return NearestPoint{s[0], 0, s[0].Dist(p)}
+	}
+	return nearestAlong((len(s)-1)/3, p, func(i int) NearestPoint {
+		var c CubicCurve
+		copy(c[:], s[i*3:i*3+4])
+		return c.Nearest(p)
+	})
+}
+
+// Nearest returns the point on the boundary of the polygon that is closest
+// to the given point, whether the given point is inside the polygon or
+// not. Edge i of the polygon runs from vertex i to the following vertex,
+// with the last edge returning to the first vertex.
+//
+// If the polygon has no vertices then the distance in the result is
+// positive infinity.
+func (p Poly) Nearest(pt Point) NearestPoint {
+	if len(p) == 0 {
+		return NearestPoint{Dist: math.Inf(1)}
+	}
+	return nearestAlong(len(p), pt, func(i int) NearestPoint {
+		return LineSeg{p[i], p[(i+1)%len(p)]}.Nearest(pt)
+	})
+}
+
+// nearestAlong returns the closest of the results of calling the given
+// function for each of n pieces of a shape, with its parameter offset by
+// the index of the piece it came from.
+func nearestAlong(n int, p Point, piece func(i int) NearestPoint) NearestPoint {
+	best := NearestPoint{Dist: math.Inf(1)}
+	for i := 0; i < n; i++ {
+		if got := piece(i); got.Dist < best.Dist {
+			got.T += float64(i)
+			best = got
+		}
+	}
+	return best
+}
+
+type curveNearest struct {
+	c    CubicCurve
+	p    Point
+	tol  float64
+	best NearestPoint
+}
+
+// search updates n.best with the closest point on the given piece of the
+// curve, which covers the given parameter range of the whole curve.
+func (n *curveNearest) search(piece CubicCurve, t0, t1 float64, depth int) {
+	if piece.hullBounds().Dist(n.p) > n.best.Dist {
+		return
+	}
+	if depth >= 48 || piece.flat(n.tol) {
+		near := LineSeg{piece[0], piece[3]}.Nearest(n.p)
+		n.refine(t0 + near.T*(t1-t0))
+		return
+	}
+
+	// Searching the closer half first makes it more likely that we can
+	// skip the other.
+	a, b := piece.Split(0.5)
+	tm := (t0 + t1) / 2
+	if b.hullBounds().Dist(n.p) < a.hullBounds().Dist(n.p) {
+		n.search(b, tm, t1, depth+1)
+		n.search(a, t0, tm, depth+1)
+		return
+	}
+	n.search(a, t0, tm, depth+1)
+	n.search(b, tm, t1, depth+1)
+}
+
+// refine improves an approximate closest point at parameter t using
+// Newton's method to find where the curve is perpendicular to the
+// direction to n.p, and then updates n.best if the result is closer.
+func (n *curveNearest) refine(t float64) {
+	try := func(t float64) bool {
+		q := n.c.At(t)
+		d := q.Dist(n.p)
+		if d < n.best.Dist || (d == n.best.Dist && t < n.best.T) {
+			n.best = NearestPoint{q, t, d}
+			return true
+		}
+		return false
+	}
+	try(t)
+	for i := 0; i < 8; i++ {
+		e := n.c.At(t).Sub(n.p)
+		d1, d2 := n.c.Derivative(t), n.c.SecondDerivative(t)
+		den := d1.LenSq() + e.Dot(d2)
+		if den == 0 {
+			return
+		}
+		t = math.Max(0, math.Min(1, t-e.Dot(d1)/den))
+		if !try(t) {
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestNearest(t *testing.T) {
+	arch := CubicCurve{{0, 0}, {0, 10}, {10, 10}, {10, 0}}
+
+	tests := []struct {
+		Shape interface{ Nearest(Point) NearestPoint }
+		Point Point
+		Want  NearestPoint
+	}{
+		{
+			LineSeg{{0, 0}, {4, 0}},
+			Point{1, 3},
+			NearestPoint{Point{1, 0}, 0.25, 3},
+		},
+		{
+			LineSeg{{0, 0}, {4, 0}},
+			Point{7, 4},
+			NearestPoint{Point{4, 0}, 1, 5},
+		},
+		{
+			LineSeg{{2, 2}, {2, 2}},
+			Point{5, 6},
+			NearestPoint{Point{2, 2}, 0, 5},
+		},
+		{
+			arch,
+			Point{5, 10},
+			NearestPoint{Point{5, 7.5}, 0.5, 2.5},
+		},
+		{
+			arch,
+			Point{-3, -4},
+			NearestPoint{Point{0, 0}, 0, 5},
+		},
+		{
+			// equally close to both ends of the curve
+			arch,
+			Point{5, -1},
+			NearestPoint{Point{0, 0}, 0, math.Hypot(5, 1)},
+		},
+		{
+			QuadraticCurve{{0, 0}, {5, 10}, {10, 0}},
+			Point{5, 8},
+			NearestPoint{Point{5, 5}, 0.5, 3},
+		},
+		{
+			LineSegSeq{{0, 0}, {4, 0}, {4, 4}},
+			Point{6, 3},
+			NearestPoint{Point{4, 3}, 1.75, 2},
+		},
+		{
+			LineSegSeq{{1, 1}},
+			Point{4, 5},
+			NearestPoint{Point{1, 1}, 0, 5},
+		},
+		{
+			LineSegSeq(nil),
+			Point{4, 5},
+			NearestPoint{Dist: math.Inf(1)},
+		},
+		{
+			CubicCurveSeq{
+				{-10, 0},
+				{-10, 0}, {0, 0}, {0, 0},
+				{0, 10}, {10, 10}, {10, 0},
+			},
+			Point{5, 10},
+			NearestPoint{Point{5, 7.5}, 1.5, 2.5},
+		},
+		{
+			// inside the polygon, nearest to the closing edge
+			Poly{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+			Point{1, 4},
+			NearestPoint{Point{0, 4}, 3.6, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v", test.Shape, test.Point), func(t *testing.T) {
+			got := test.Shape.Nearest(test.Point)
+			if math.IsInf(test.Want.Dist, 1) {
+				for _, problem := range deep.Equal(got, test.Want) {
+					t.Error(problem)
+				}
+				return
+			}
+			if !got.Point.ApproxEqual(test.Want.Point, 1e-9) || math.Abs(got.T-test.Want.T) > 1e-9 || math.Abs(got.Dist-test.Want.Dist) > 1e-9 {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
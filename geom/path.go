@@ -0,0 +1,267 @@
+package geom
+
+// Path represents a shape made of any number of separate contours, each of
+// which is a sequence of connected segments of various kinds.
+//
+// The methods that build a path return the updated path, in the same way
+// as the built-in append function. The result may share its backing arrays
+// with the receiver, which therefore shouldn't be used afterwards.
+type Path []Contour
+
+// Contour is a single connected part of a Path, also known as a sub-path.
+type Contour struct {
+	// Start is the point where the contour begins.
+	Start Point
+
+	// Segments are the segments of the contour, in order. Each segment
+	// begins at the point where the previous segment ends, or at Start for
+	// the first segment.
+	Segments []PathSegment
+
+	// Closed is true if the contour's end is connected back to its start.
+	// If the last segment doesn't already end at the start then the
+	// closure is an implied straight line.
+	Closed bool
+}
+
+// A PathSegment is a single segment of a Contour. The types implementing
+// PathSegment are LineSeg, QuadraticCurve, CubicCurve and Arc, and so a
+// segment's type can be determined using a type switch.
+type PathSegment interface {
+	Bounder
+
+	// endpoints returns the points where the segment begins and ends.
+	endpoints() (Point, Point)
+
+	// appendCubicCurves appends to dst the cubic curves that are equivalent
+	// to the segment, or that approximate it in the case of an Arc.
+	appendCubicCurves(dst []CubicCurve) []CubicCurve
+}
+
+func (s LineSeg) endpoints() (Point, Point) { return s[0], s[1] }
+
+func (c QuadraticCurve) endpoints() (Point, Point) { return c[0], c[2] }
+
+func (c CubicCurve) endpoints() (Point, Point) { return c[0], c[3] }
+
+func (a Arc) endpoints() (Point, Point) { return a.From, a.To }
+
+func (s LineSeg) appendCubicCurves(dst []CubicCurve) []CubicCurve {
+	return append(dst, s.CubicCurve())
+}
+
+func (c QuadraticCurve) appendCubicCurves(dst []CubicCurve) []CubicCurve {
+	return append(dst, c.CubicCurve())
+}
+
+func (c CubicCurve) appendCubicCurves(dst []CubicCurve) []CubicCurve {
+	return append(dst, c)
+}
+
+func (a Arc) appendCubicCurves(dst []CubicCurve) []CubicCurve {
+	return append(dst, a.CubicCurves()...)
+}
+
+// CurrentPoint returns the point where the next segment added to the path
+// will begin: the end of the last contour, or the start of that contour if
+// it is closed. The current point of an empty path is the origin.
+func (p Path) CurrentPoint() Point {
+	if len(p) == 0 {
+		return Origin
+	}
+	c := p[len(p)-1]
+	if c.Closed {
+		return c.Start
+	}
+	return c.End()
+}
+
+// MoveTo begins a new contour at the given point.
+func (p Path) MoveTo(pt Point) Path {
+	return append(p, Contour{Start: pt})
+}
+
+// LineTo adds a straight line from the current point to the given point.
+func (p Path) LineTo(pt Point) Path {
+	return p.Append(LineSeg{p.CurrentPoint(), pt})
+}
+
+// QuadTo adds a quadratic bezier curve from the current point to the given
+// end point, with the given control point.
+func (p Path) QuadTo(c, end Point) Path {
+	return p.Append(QuadraticCurve{p.CurrentPoint(), c, end})
+}
+
+// CubicTo adds a cubic bezier curve from the current point to the given end
+// point, with the given control points.
+func (p Path) CubicTo(c1, c2, end Point) Path {
+	return p.Append(CubicCurve{p.CurrentPoint(), c1, c2, end})
+}
+
+// ArcTo adds an elliptical arc from the current point to the given end
+// point. The other arguments are as for the fields of Arc.
+func (p Path) ArcTo(radii Point, rotation float64, largeArc, sweep bool, end Point) Path {
+	return p.Append(Arc{
+		From:     p.CurrentPoint(),
+		To:       end,
+		Radii:    radii,
+		Rotation: rotation,
+		LargeArc: largeArc,
+		Sweep:    sweep,
+	})
+}
+
+// Close closes the last contour of the path, connecting its end back to its
+// start. Closing an empty path or a contour that is already closed has no
+// effect.
+func (p Path) Close() Path {
+	if len(p) == 0 {
+		return p
+	}
+	p[len(p)-1].Closed = true
+	return p
+}
+
+// Append adds the given segment to the path.
+//
+// The segment extends the last contour if that contour is open and ends
+// where the segment begins. Otherwise, the segment begins a new contour. In
+// particular, adding a segment after closing a contour begins a new
+// contour, which starts at the same point as the closed one if the segment
+// does.
+func (p Path) Append(seg PathSegment) Path {
+	start, _ := seg.endpoints()
+	if len(p) == 0 || p[len(p)-1].Closed || p[len(p)-1].End() != start {
+		p = p.MoveTo(start)
+	}
+	c := &p[len(p)-1]
+	c.Segments = append(c.Segments, seg)
+	return p
+}
+
+// Bounds returns the smallest normalized rectangle containing all of the
+// path's contours, or ZeroRect if the path is empty.
+func (p Path) Bounds() Rect {
+	if len(p) == 0 {
+		return ZeroRect
+	}
+	r := p[0].Bounds()
+	for _, c := range p[1:] {
+		r = r.Union(c.Bounds())
+	}
+	return r
+}
+
+// CubicCurveSeqs converts each contour of the path that has at least one
+// segment into a sequence of cubic bezier curves, as described for method
+// Contour.CubicCurveSeq.
+func (p Path) CubicCurveSeqs() []CubicCurveSeq {
+	var ret []CubicCurveSeq
+	for _, c := range p {
+		if seq := c.CubicCurveSeq(); len(seq) > 1 {
+			ret = append(ret, seq)
+		}
+	}
+	return ret
+}
+
+// End returns the point where the last segment of the contour ends, or its
+// start point if it has no segments. The implied closing line of a closed
+// contour isn't considered.
+func (c Contour) End() Point {
+	if len(c.Segments) == 0 {
+		return c.Start
+	}
+	_, end := c.Segments[len(c.Segments)-1].endpoints()
+	return end
+}
+
+// Bounds returns the smallest normalized rectangle containing the contour.
+func (c Contour) Bounds() Rect {
+	r := c.Start.Bounds()
+	for _, s := range c.Segments {
+		r = r.Union(s.Bounds())
+	}
+	return r
+}
+
+// CubicCurveSeq converts the contour into a sequence of cubic bezier
+// curves.
+//
+// Lines are converted to curves whose control points are at their
+// endpoints, quadratic curves are converted to their exact cubic
+// equivalents, and arcs are approximated as described for
+// Arc.CubicCurves. If the contour is closed then a line is added back to
+// its start point, unless it already ends there.
+func (c Contour) CubicCurveSeq() CubicCurveSeq {
+	var curves []CubicCurve
+	for _, s := range c.Segments {
+		curves = s.appendCubicCurves(curves)
+	}
+	if end := c.End(); c.Closed && end != c.Start {
+		curves = append(curves, LineSeg{end, c.Start}.CubicCurve())
+	}
+	ret := BeginCubicCurveSeq(c.Start, len(curves))
+	for _, curve := range curves {
+		ret = ret.Append(curve[1], curve[2], curve[3])
+	}
+	return ret
+}
+
+// Flatten approximates the contour as a sequence of line segments that are
+// nowhere further than the given tolerance from it, as described for
+// CubicCurve.Flatten. Straight lines are preserved exactly. If the contour
+// is closed then the result ends back at its start point.
+func (c Contour) Flatten(tolerance float64) LineSegSeq {
+	ret := LineSegSeq{c.Start}
+	for _, s := range c.Segments {
+		if l, ok := s.(LineSeg); ok {
+			ret = append(ret, l[1])
+			continue
+		}
+		for _, curve := range s.appendCubicCurves(nil) {
+			ret = append(ret, curve.Flatten(tolerance)[1:]...)
+		}
+	}
+	if c.Closed && ret[len(ret)-1] != c.Start {
+		ret = append(ret, c.Start)
+	}
+	return ret
+}
+
+// Transform returns a copy of the path with all of its points transformed
+// by the given transformation.
+//
+// Bezier curves are transformed exactly by transforming their control
+// points. Arcs are first converted into cubic curves as described for
+// Arc.CubicCurves.
+func (p Path) Transform(a Affine) Path {
+	if p == nil {
+		return nil
+	}
+	ret := make(Path, len(p))
+	for i, c := range p {
+		nc := Contour{
+			Start:    a.Apply(c.Start),
+			Segments: make([]PathSegment, 0, len(c.Segments)),
+			Closed:   c.Closed,
+		}
+		for _, s := range c.Segments {
+			switch s := s.(type) {
+			case LineSeg:
+				nc.Segments = append(nc.Segments, LineSeg{a.Apply(s[0]), a.Apply(s[1])})
+			case QuadraticCurve:
+				nc.Segments = append(nc.Segments, QuadraticCurve{a.Apply(s[0]), a.Apply(s[1]), a.Apply(s[2])})
+			default:
+				for _, curve := range s.appendCubicCurves(nil) {
+					for j, pt := range curve {
+						curve[j] = a.Apply(pt)
+					}
+					nc.Segments = append(nc.Segments, curve)
+				}
+			}
+		}
+		ret[i] = nc
+	}
+	return ret
+}
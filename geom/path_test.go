@@ -0,0 +1,219 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPathBuild(t *testing.T) {
+	tests := []struct {
+		Path Path
+		Want Path
+	}{
+		{
+			Path(nil).Close(),
+			nil,
+		},
+		{
+			Path(nil).LineTo(Point{1, 0}),
+			Path{
+				{Start: Point{0, 0}, Segments: []PathSegment{LineSeg{{0, 0}, {1, 0}}}},
+			},
+		},
+		{
+			Path(nil).MoveTo(Point{1, 1}).LineTo(Point{2, 1}).QuadTo(Point{3, 1}, Point{3, 2}).Close(),
+			Path{
+				{
+					Start: Point{1, 1},
+					Segments: []PathSegment{
+						LineSeg{{1, 1}, {2, 1}},
+						QuadraticCurve{{2, 1}, {3, 1}, {3, 2}},
+					},
+					Closed: true,
+				},
+			},
+		},
+		{
+			// Drawing after closing continues from the start of the closed
+			// contour, but in a new contour.
+			Path(nil).MoveTo(Point{1, 1}).LineTo(Point{2, 1}).Close().LineTo(Point{1, 2}),
+			Path{
+				{Start: Point{1, 1}, Segments: []PathSegment{LineSeg{{1, 1}, {2, 1}}}, Closed: true},
+				{Start: Point{1, 1}, Segments: []PathSegment{LineSeg{{1, 1}, {1, 2}}}},
+			},
+		},
+		{
+			// A segment that doesn't join the end of the path begins a new
+			// contour.
+			Path(nil).Append(LineSeg{{0, 0}, {1, 0}}).Append(CubicCurve{{1, 0}, {2, 0}, {2, 1}, {2, 2}}).Append(LineSeg{{5, 5}, {6, 6}}),
+			Path{
+				{
+					Start: Point{0, 0},
+					Segments: []PathSegment{
+						LineSeg{{0, 0}, {1, 0}},
+						CubicCurve{{1, 0}, {2, 0}, {2, 1}, {2, 2}},
+					},
+				},
+				{Start: Point{5, 5}, Segments: []PathSegment{LineSeg{{5, 5}, {6, 6}}}},
+			},
+		},
+		{
+			Path(nil).MoveTo(Point{1, 0}).ArcTo(Point{1, 1}, 0, false, true, Point{-1, 0}),
+			Path{
+				{
+					Start: Point{1, 0},
+					Segments: []PathSegment{
+						Arc{From: Point{1, 0}, To: Point{-1, 0}, Radii: Point{1, 1}, Sweep: true},
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if diff := deep.Equal(test.Path, test.Want); diff != nil {
+				for _, problem := range diff {
+					t.Error(problem)
+				}
+			}
+		})
+	}
+}
+
+func TestPathCubicCurveSeqs(t *testing.T) {
+	path := Path(nil).
+		MoveTo(Point{0, 0}).
+		LineTo(Point{2, 0}).
+		QuadTo(Point{2, 2}, Point{0, 2}).
+		Close().
+		MoveTo(Point{5, 5})
+
+	got := path.CubicCurveSeqs()
+	want := []CubicCurveSeq{
+		{
+			{0, 0},
+			{0, 0}, {2, 0}, {2, 0},
+			QuadraticCurve{{2, 0}, {2, 2}, {0, 2}}.CubicCurve()[1],
+			QuadraticCurve{{2, 0}, {2, 2}, {0, 2}}.CubicCurve()[2],
+			{0, 2},
+			{0, 2}, {0, 0}, {0, 0},
+		},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		for _, problem := range diff {
+			t.Error(problem)
+		}
+	}
+}
+
+func TestContourFlatten(t *testing.T) {
+	c := Path(nil).
+		MoveTo(Point{0, 0}).
+		LineTo(Point{4, 0}).
+		ArcTo(Point{2, 2}, 0, false, true, Point{0, 0}).
+		Close()[0]
+
+	got := c.Flatten(0.01)
+	if got[0] != (Point{0, 0}) || got[1] != (Point{4, 0}) {
+		t.Errorf("line not preserved: %#v", got[:2])
+	}
+	if got[len(got)-1] != (Point{0, 0}) {
+		t.Errorf("doesn't end at start: %#v", got[len(got)-1])
+	}
+	for _, p := range got[2 : len(got)-1] {
+		if d := math.Abs(p.Dist(Point{2, 0}) - 2); d > 0.01 {
+			t.Errorf("point %#v is %g from the arc", p, d)
+		}
+	}
+}
+
+func TestArcBounds(t *testing.T) {
+	tests := []struct {
+		Arc  Arc
+		Want Rect
+	}{
+		{
+			// Upper half of the unit circle
+			Arc{From: Point{1, 0}, To: Point{-1, 0}, Radii: Point{1, 1}, Sweep: true},
+			Rect{{-1, 0}, {1, 1}},
+		},
+		{
+			// Lower half of the unit circle
+			Arc{From: Point{1, 0}, To: Point{-1, 0}, Radii: Point{1, 1}},
+			Rect{{-1, -1}, {1, 0}},
+		},
+		{
+			// Radii too small, so scaled up to reach the endpoints
+			Arc{From: Point{2, 0}, To: Point{-2, 0}, Radii: Point{1, 1}, Sweep: true},
+			Rect{{-2, 0}, {2, 2}},
+		},
+		{
+			// Zero radius is a straight line
+			Arc{From: Point{2, 0}, To: Point{0, 3}},
+			Rect{{0, 0}, {2, 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Arc), func(t *testing.T) {
+			got := test.Arc.Bounds()
+			if !got[0].ApproxEqual(test.Want[0], 1e-9) || !got[1].ApproxEqual(test.Want[1], 1e-9) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestArcCubicCurves(t *testing.T) {
+	a := Arc{From: Point{1, 0}, To: Point{0, -1}, Radii: Point{1, 1}, LargeArc: true, Sweep: true}
+	curves := a.CubicCurves()
+	if got, want := len(curves), 3; got != want {
+		t.Fatalf("wrong number of curves %d; want %d", got, want)
+	}
+	if curves[0][0] != a.From || curves[2][3] != a.To {
+		t.Errorf("wrong endpoints")
+	}
+	for _, c := range curves {
+		if d := math.Abs(c.At(0.5).Len() - 1); d > 1e-3 {
+			t.Errorf("curve midpoint is %g from the circle", d)
+		}
+	}
+}
+
+func TestPathTransform(t *testing.T) {
+	path := Path(nil).
+		MoveTo(Point{0, 0}).
+		LineTo(Point{1, 0}).
+		QuadTo(Point{1, 1}, Point{0, 1}).
+		Close()
+
+	got := path.Transform(ScaleAffine(Point{2, 3}).Then(TranslateAffine(Point{1, 1})))
+	want := Path{
+		{
+			Start: Point{1, 1},
+			Segments: []PathSegment{
+				LineSeg{{1, 1}, {3, 1}},
+				QuadraticCurve{{3, 1}, {3, 4}, {1, 4}},
+			},
+			Closed: true,
+		},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		for _, problem := range diff {
+			t.Error(problem)
+		}
+	}
+
+	// Arcs become cubic curves.
+	arc := Path(nil).ArcTo(Point{1, 1}, 0, false, true, Point{2, 0})
+	got = arc.Transform(IdentityAffine)
+	if diff := deep.Equal(got[0].Segments, []PathSegment{arc[0].Segments[0].(Arc).CubicCurves()[0], arc[0].Segments[0].(Arc).CubicCurves()[1]}); diff != nil {
+		for _, problem := range diff {
+			t.Error(problem)
+		}
+	}
+}
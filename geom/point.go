@@ -1,5 +1,9 @@
 package geom
 
+import (
+	"math"
+)
+
 // Point represents a single 2D point.
 type Point struct {
 	X, Y float64
@@ -28,3 +32,107 @@ func (p Point) Scale(m float64) Point {
 func (p Point) Mul(o Point) Point {
 	return Point{p.X * o.X, p.Y * o.Y}
 }
+
+// Dot returns the dot product of the receiver and the given other point,
+// each treated as a vector from the origin.
+func (p Point) Dot(o Point) float64 {
+	return p.X*o.X + p.Y*o.Y
+}
+
+// Cross returns the Z component of the cross product of the receiver and
+// the given other point, each treated as a vector from the origin.
+//
+// The result is positive if o is anti-clockwise from p, negative if it is
+// clockwise and zero if the two are parallel, assuming a Y axis that
+// increases upward. Its magnitude is the area of the parallelogram with
+// the two vectors as sides.
+func (p Point) Cross(o Point) float64 {
+	return p.X*o.Y - p.Y*o.X
+}
+
+// Len returns the distance from the origin to the point, which is the
+// length of the point treated as a vector.
+func (p Point) Len() float64 {
+	return math.Hypot(p.X, p.Y)
+}
+
+// LenSq returns the square of the result of Len. It is cheaper to compute,
+// and so is preferable when only comparing lengths.
+func (p Point) LenSq() float64 {
+	return p.Dot(p)
+}
+
+// Dist returns the distance between the receiver and the given other point.
+func (p Point) Dist(o Point) float64 {
+	return o.Sub(p).Len()
+}
+
+// Normalize returns a vector of length one in the same direction as the
+// receiver, or the origin if the receiver is itself the origin.
+func (p Point) Normalize() Point {
+	l := p.Len()
+	if l == 0 {
+		return Origin
+	}
+	return p.Scale(1 / l)
+}
+
+// Perp returns the receiver rotated a quarter turn anti-clockwise about the
+// origin, assuming a Y axis that increases upward.
+func (p Point) Perp() Point {
+	return Point{-p.Y, p.X}
+}
+
+// Rotate returns the receiver rotated anti-clockwise about the origin by
+// the given angle in radians, assuming a Y axis that increases upward.
+func (p Point) Rotate(angle float64) Point {
+	sin, cos := math.Sincos(angle)
+	return Point{p.X*cos - p.Y*sin, p.X*sin + p.Y*cos}
+}
+
+// Angle returns the angle in radians from the positive X axis to the
+// receiver, treated as a vector from the origin. The result is in the range
+// [-Pi, Pi] and is positive for points above the X axis.
+func (p Point) Angle() float64 {
+	return math.Atan2(p.Y, p.X)
+}
+
+// AngleBetween returns the angle in radians through which the receiver
+// must be rotated anti-clockwise to point in the same direction as the
+// given other point, each treated as a vector from the origin. The result
+// is in the range [-Pi, Pi], with negative values representing clockwise
+// rotations.
+func (p Point) AngleBetween(o Point) float64 {
+	return math.Atan2(p.Cross(o), p.Dot(o))
+}
+
+// Lerp interpolates linearly between the receiver and the given other
+// point, returning the receiver when t is zero and the other point when t
+// is one. Values of t outside that range extrapolate along the same line.
+func (p Point) Lerp(o Point, t float64) Point {
+	return p.Add(o.Sub(p).Scale(t))
+}
+
+// Project returns the projection of the receiver onto the line through the
+// origin in the direction of the given other point, or the origin if the
+// other point is itself the origin.
+func (p Point) Project(o Point) Point {
+	l := o.LenSq()
+	if l == 0 {
+		return Origin
+	}
+	return o.Scale(p.Dot(o) / l)
+}
+
+// ApproxEqual returns true if each coordinate of the receiver differs from
+// the corresponding coordinate of the given other point by no more than
+// epsilon.
+func (p Point) ApproxEqual(o Point, epsilon float64) bool {
+	return math.Abs(p.X-o.X) <= epsilon && math.Abs(p.Y-o.Y) <= epsilon
+}
+
+// Bounds returns an empty rectangle whose corners are both at the point.
+// This method is present only to implement Bounder.
+func (p Point) Bounds() Rect {
+	return Rect{p, p}
+}
@@ -0,0 +1,195 @@
+package geom
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/apparentlymart/go-geometry/geom/robust"
+)
+
+// Coord is the set of types that can be used as coordinates of a PointOf.
+type Coord interface {
+	float32 | float64 | int32 | int64
+}
+
+// PointOf represents a single 2D point with coordinates of type T.
+//
+// Most of this package works only with Point, whose coordinates are
+// float64. PointOf allows storing large numbers of points more compactly,
+// using float32, or exactly, using integers, converting to and from Point
+// as needed.
+//
+// Arithmetic on a PointOf uses the arithmetic of T, and so for integer
+// coordinates it may overflow. For example, the result of Cross is exact
+// for int64 coordinates only if they are no larger in magnitude than 2^31.
+// Function Orient and method CrossSign instead give exact results for all
+// coordinates.
+type PointOf[T Coord] struct {
+	X, Y T
+}
+
+// PointFrom converts a Point to a PointOf with the given coordinate type.
+//
+// For integer coordinate types, each coordinate is rounded to the nearest
+// integer, with halves rounded away from zero, and then clamped to the
+// range of the type. NaN converts to zero.
+func PointFrom[T Coord](p Point) PointOf[T] {
+	return PointOf[T]{coordFrom[T](p.X), coordFrom[T](p.Y)}
+}
+
+// ConvertPoint converts a PointOf from one coordinate type to another, as
+// if by converting it first to a Point and then using PointFrom.
+func ConvertPoint[U, T Coord](p PointOf[T]) PointOf[U] {
+	return PointFrom[U](p.Float64())
+}
+
+// PointsFrom converts a slice of Point to a new slice of PointOf with the
+// given coordinate type, converting each point as described for
+// PointFrom.
+func PointsFrom[T Coord](pts []Point) []PointOf[T] {
+	if pts == nil {
+		return nil
+	}
+	ret := make([]PointOf[T], len(pts))
+	for i, p := range pts {
+		ret[i] = PointFrom[T](p)
+	}
+	return ret
+}
+
+// PointsFloat64 converts a slice of PointOf to a new slice of Point.
+func PointsFloat64[T Coord](pts []PointOf[T]) []Point {
+	if pts == nil {
+		return nil
+	}
+	ret := make([]Point, len(pts))
+	for i, p := range pts {
+		ret[i] = p.Float64()
+	}
+	return ret
+}
+
+// Float64 returns the Point with the same coordinates as the receiver.
+// The result is exact for all coordinate types except int64 values larger
+// in magnitude than 2^53.
+func (p PointOf[T]) Float64() Point {
+	return Point{float64(p.X), float64(p.Y)}
+}
+
+// Add returns the sum of the receiver and the given other point.
+func (p PointOf[T]) Add(o PointOf[T]) PointOf[T] {
+	return PointOf[T]{p.X + o.X, p.Y + o.Y}
+}
+
+// Sub returns the difference between the receiver and the given other point.
+func (p PointOf[T]) Sub(o PointOf[T]) PointOf[T] {
+	return PointOf[T]{p.X - o.X, p.Y - o.Y}
+}
+
+// Scale multiplies the point by the given scale factor.
+func (p PointOf[T]) Scale(m T) PointOf[T] {
+	return PointOf[T]{p.X * m, p.Y * m}
+}
+
+// Mul multiplies each dimension in the receiver by the corresponding dimension
+// in the given point, creating a non-uniform scale.
+func (p PointOf[T]) Mul(o PointOf[T]) PointOf[T] {
+	return PointOf[T]{p.X * o.X, p.Y * o.Y}
+}
+
+// Dot returns the dot product of the receiver and the given other point,
+// each treated as a vector from the origin.
+func (p PointOf[T]) Dot(o PointOf[T]) T {
+	return p.X*o.X + p.Y*o.Y
+}
+
+// Cross returns the Z component of the cross product of the receiver and
+// the given other point, as described for Point.Cross.
+func (p PointOf[T]) Cross(o PointOf[T]) T {
+	return p.X*o.Y - p.Y*o.X
+}
+
+// CrossSign returns the sign of the cross product of the receiver and the
+// given other point, as returned by Cross: 1 if it is positive, -1 if it is
+// negative and zero if it is zero. Unlike Cross, the result is exact for all
+// coordinates.
+func (p PointOf[T]) CrossSign(o PointOf[T]) int {
+	return Orient(PointOf[T]{}, p, o)
+}
+
+// Orient returns 1 if the points a, b and c are in anti-clockwise order, -1
+// if they are in clockwise order, and zero if they are collinear, assuming
+// a Y axis that increases upward.
+//
+// The result is exact for all coordinates, including integer coordinates
+// whose differences and products would overflow T.
+func Orient[T Coord](a, b, c PointOf[T]) int {
+	if !exactFloat64(a) || !exactFloat64(b) || !exactFloat64(c) {
+		return orientBig(a, b, c)
+	}
+	o := robust.Orient2D(
+		float64(a.X), float64(a.Y),
+		float64(b.X), float64(b.Y),
+		float64(c.X), float64(c.Y),
+	)
+	switch {
+	case o > 0:
+		return 1
+	case o < 0:
+		return -1
+	}
+	return 0
+}
+
+// exactFloat64 returns true if the coordinates of the given point convert
+// to float64 exactly, which is true for all coordinate types except int64
+// values larger in magnitude than 2^53.
+func exactFloat64[T Coord](p PointOf[T]) bool {
+	const limit = 1 << 53
+	switch p := any(p).(type) {
+	case PointOf[int64]:
+		return p.X >= -limit && p.X <= limit && p.Y >= -limit && p.Y <= limit
+	}
+	return true
+}
+
+// orientBig is the implementation of Orient for integer coordinates that
+// can't be converted to float64 exactly, using arbitrary precision
+// arithmetic.
+func orientBig[T Coord](a, b, c PointOf[T]) int {
+	coord := func(v T) *big.Int {
+		return big.NewInt(int64(v))
+	}
+	bx := new(big.Int).Sub(coord(b.X), coord(a.X))
+	by := new(big.Int).Sub(coord(b.Y), coord(a.Y))
+	cx := new(big.Int).Sub(coord(c.X), coord(a.X))
+	cy := new(big.Int).Sub(coord(c.Y), coord(a.Y))
+	return bx.Mul(bx, cy).Cmp(by.Mul(by, cx))
+}
+
+// coordFrom converts a float64 to the given coordinate type, as described
+// for PointFrom.
+func coordFrom[T Coord](v float64) T {
+	var zero T
+	switch any(zero).(type) {
+	case int32:
+		return T(clampRound(v, math.MinInt32, math.MaxInt32))
+	case int64:
+		// The largest int64 isn't exactly representable as a float64, so
+		// we use the largest float64 below it instead.
+		return T(clampRound(v, math.MinInt64, math.Nextafter(math.MaxInt64, 0)))
+	}
+	return T(v)
+}
+
+func clampRound(v, min, max float64) float64 {
+	switch {
+	case math.IsNaN(v):
+		return 0
+	case v <= min:
+		return min
+	case v >= max:
+		return max
+	}
+	return math.Round(v)
+}
@@ -0,0 +1,150 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPointFrom(t *testing.T) {
+	tests := []struct {
+		Point Point
+		Got   interface{}
+		Want  interface{}
+	}{
+		{
+			Point{1.5, -2.25},
+			PointFrom[float32](Point{1.5, -2.25}),
+			PointOf[float32]{1.5, -2.25},
+		},
+		{
+			Point{1.5, -2.5},
+			PointFrom[int32](Point{1.5, -2.5}),
+			PointOf[int32]{2, -3},
+		},
+		{
+			Point{1.4, -2.6},
+			PointFrom[int64](Point{1.4, -2.6}),
+			PointOf[int64]{1, -3},
+		},
+		{
+			Point{1e20, -1e20},
+			PointFrom[int32](Point{1e20, -1e20}),
+			PointOf[int32]{math.MaxInt32, math.MinInt32},
+		},
+		{
+			Point{1e20, math.NaN()},
+			PointFrom[int64](Point{1e20, math.NaN()}),
+			PointOf[int64]{math.MaxInt64 - 1023, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T %#v", test.Want, test.Point), func(t *testing.T) {
+			for _, problem := range deep.Equal(test.Got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestPointOfConversions(t *testing.T) {
+	pts := []Point{{0.5, 1.25}, {-3, 4}, {100.75, -0.1}}
+
+	f32 := PointsFrom[float32](pts)
+	for _, problem := range deep.Equal(PointsFloat64(f32), []Point{{0.5, 1.25}, {-3, 4}, {100.75, float64(float32(-0.1))}}) {
+		t.Error(problem)
+	}
+
+	ints := PointsFrom[int32](pts)
+	for _, problem := range deep.Equal(ints, []PointOf[int32]{{1, 1}, {-3, 4}, {101, 0}}) {
+		t.Error(problem)
+	}
+	for _, problem := range deep.Equal(ConvertPoint[int64](f32[1]), PointOf[int64]{-3, 4}) {
+		t.Error(problem)
+	}
+
+	if got := PointsFrom[float32](nil); got != nil {
+		t.Errorf("wrong result for nil %#v; want nil", got)
+	}
+}
+
+func TestPointOfArithmetic(t *testing.T) {
+	a, b := PointOf[int64]{3, 4}, PointOf[int64]{-2, 5}
+
+	for _, problem := range deep.Equal(a.Add(b), PointOf[int64]{1, 9}) {
+		t.Error(problem)
+	}
+	for _, problem := range deep.Equal(a.Sub(b), PointOf[int64]{5, -1}) {
+		t.Error(problem)
+	}
+	for _, problem := range deep.Equal(a.Scale(3), PointOf[int64]{9, 12}) {
+		t.Error(problem)
+	}
+	for _, problem := range deep.Equal(a.Mul(b), PointOf[int64]{-6, 20}) {
+		t.Error(problem)
+	}
+	if got, want := a.Dot(b), int64(14); got != want {
+		t.Errorf("wrong dot product %d; want %d", got, want)
+	}
+	if got, want := a.Cross(b), int64(23); got != want {
+		t.Errorf("wrong cross product %d; want %d", got, want)
+	}
+	if got, want := a.Cross(b), a.Float64().Cross(b.Float64()); float64(got) != want {
+		t.Errorf("cross product %d doesn't match float64 result %g", got, want)
+	}
+}
+
+func TestPointOfOrient(t *testing.T) {
+	const (
+		maxInt64, minInt64 = math.MaxInt64, math.MinInt64
+		maxInt32, minInt32 = math.MaxInt32, math.MinInt32
+	)
+
+	t.Run("int64", func(t *testing.T) {
+		tests := []struct {
+			A, B, C PointOf[int64]
+			Want    int
+		}{
+			{PointOf[int64]{0, 0}, PointOf[int64]{1, 0}, PointOf[int64]{0, 1}, 1},
+			{PointOf[int64]{minInt64, minInt64}, PointOf[int64]{0, 0}, PointOf[int64]{maxInt64, maxInt64}, 0},
+			{PointOf[int64]{minInt64, minInt64}, PointOf[int64]{maxInt64, maxInt64}, PointOf[int64]{maxInt64, maxInt64 - 1}, -1},
+			{PointOf[int64]{minInt64, minInt64}, PointOf[int64]{maxInt64, maxInt64 - 1}, PointOf[int64]{maxInt64, maxInt64}, 1},
+		}
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("%#v %#v %#v", test.A, test.B, test.C), func(t *testing.T) {
+				if got := Orient(test.A, test.B, test.C); got != test.Want {
+					t.Errorf("wrong result %d; want %d", got, test.Want)
+				}
+			})
+		}
+
+		// The cross product of these overflows int64, but is exactly -1.
+		p, q := PointOf[int64]{maxInt64, maxInt64 - 1}, PointOf[int64]{maxInt64 - 1, maxInt64 - 2}
+		if got, want := p.CrossSign(q), -1; got != want {
+			t.Errorf("wrong cross product sign %d; want %d", got, want)
+		}
+	})
+	t.Run("int32", func(t *testing.T) {
+		a := PointOf[int32]{minInt32, minInt32}
+		b := PointOf[int32]{maxInt32, maxInt32}
+		c := PointOf[int32]{maxInt32, maxInt32 - 1}
+		if got, want := Orient(a, b, c), -1; got != want {
+			t.Errorf("wrong result %d; want %d", got, want)
+		}
+		if got, want := b.CrossSign(c), -1; got != want {
+			t.Errorf("wrong cross product sign %d; want %d", got, want)
+		}
+	})
+	t.Run("float64", func(t *testing.T) {
+		a, b, c := PointOf[float64]{0, 0}, PointOf[float64]{1, 0}, PointOf[float64]{0, 1}
+		if got, want := Orient(a, b, c), 1; got != want {
+			t.Errorf("wrong result %d; want %d", got, want)
+		}
+		if got, want := c.CrossSign(b), -1; got != want {
+			t.Errorf("wrong cross product sign %d; want %d", got, want)
+		}
+	})
+}
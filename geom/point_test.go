@@ -0,0 +1,73 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPointVector(t *testing.T) {
+	const eps = 1e-12
+	tests := []struct {
+		Name string
+		Got  interface{}
+		Want interface{}
+	}{
+		{"Dot", Point{1, 2}.Dot(Point{3, 4}), 11.0},
+		{"Cross", Point{1, 0}.Cross(Point{0, 1}), 1.0},
+		{"Cross clockwise", Point{0, 1}.Cross(Point{1, 0}), -1.0},
+		{"Len", Point{3, 4}.Len(), 5.0},
+		{"LenSq", Point{3, 4}.LenSq(), 25.0},
+		{"Dist", Point{1, 1}.Dist(Point{4, 5}), 5.0},
+		{"Normalize", Point{3, 4}.Normalize(), Point{0.6, 0.8}},
+		{"Normalize origin", Origin.Normalize(), Origin},
+		{"Perp", Point{1, 2}.Perp(), Point{-2, 1}},
+		{"Rotate", Point{1, 0}.Rotate(math.Pi / 2), Point{0, 1}},
+		{"Rotate back", Point{2, 3}.Rotate(-math.Pi), Point{-2, -3}},
+		{"Angle", Point{0, 2}.Angle(), math.Pi / 2},
+		{"Angle below", Point{1, -1}.Angle(), -math.Pi / 4},
+		{"AngleBetween", Point{1, 0}.AngleBetween(Point{0, 3}), math.Pi / 2},
+		{"AngleBetween clockwise", Point{1, 1}.AngleBetween(Point{1, -1}), -math.Pi / 2},
+		{"Lerp", Point{0, 0}.Lerp(Point{4, 2}, 0.25), Point{1, 0.5}},
+		{"Lerp beyond", Point{0, 0}.Lerp(Point{4, 2}, 1.5), Point{6, 3}},
+		{"Project", Point{2, 3}.Project(Point{5, 0}), Point{2, 0}},
+		{"Project diagonal", Point{2, 0}.Project(Point{1, 1}), Point{1, 1}},
+		{"Project onto origin", Point{2, 3}.Project(Origin), Origin},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ok := false
+			switch want := test.Want.(type) {
+			case float64:
+				ok = math.Abs(test.Got.(float64)-want) <= eps
+			case Point:
+				ok = test.Got.(Point).ApproxEqual(want, eps)
+			}
+			if !ok {
+				t.Errorf("wrong result %#v; want %#v", test.Got, test.Want)
+			}
+		})
+	}
+}
+
+func TestPointApproxEqual(t *testing.T) {
+	tests := []struct {
+		A, B Point
+		Eps  float64
+		Want bool
+	}{
+		{Point{1, 2}, Point{1, 2}, 0, true},
+		{Point{1, 2}, Point{1.05, 1.95}, 0.1, true},
+		{Point{1, 2}, Point{1.2, 2}, 0.1, false},
+		{Point{1, 2}, Point{1, 1.8}, 0.1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v", test.A, test.B), func(t *testing.T) {
+			if got := test.A.ApproxEqual(test.B, test.Eps); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
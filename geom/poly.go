@@ -1,5 +1,12 @@
 package geom
 
+import (
+	"iter"
+	"slices"
+
+	"github.com/apparentlymart/go-geometry/geom/robust"
+)
+
 // Poly represents a closed polygon as a sequence of vertices. Each vertex
 // is connected to the next by an edge, and the final vertex is implicitly
 // connected to the first.
@@ -21,21 +28,41 @@ func (p Poly) Poly() Poly {
 	return r
 }
 
-// Area returns the area enclosed by the polygon.
+// Bounds returns the smallest normalized rectangle containing the polygon.
+func (p Poly) Bounds() Rect {
+	return BoundingRect(p)
+}
+
+// Area returns the area enclosed by the polygon, regardless of the order of
+// its vertices.
+//
+// For a self-intersecting polygon the result is the magnitude of the net
+// signed area, in which parts wound in opposite directions cancel out.
 func (p Poly) Area() float64 {
-	a := p.dirArea()
+	a := p.dirArea() * 0.5
 	if a < 0.0 {
 		return a * -1.0
 	}
 	return a
 }
 
+// Contains returns true if the given point is inside the polygon or on its
+// boundary.
+//
+// For a self-intersecting polygon, a point is considered to be inside if
+// the polygon winds around it a non-zero number of times.
+func (p Poly) Contains(pt Point) bool {
+	return p.onBoundary(pt) || p.Winding(pt) != 0
+}
+
 // Facing returns either 1 or -1 depending on the ordering of the points.
 // Assuming an X axis that increases to the right and a Y axis that increases
 // upward, facing is 1 if the points are clockwise, and -1 for anti-clockwise.
+//
+// The facing is determined exactly, even for polygons enclosing very
+// little area. A polygon that encloses no area at all has facing 1.
 func (p Poly) Facing() int {
-	a := p.dirArea()
-	if a < 0.0 {
+	if p.orientation() > 0 {
 		return -1
 	}
 	return 1
@@ -51,6 +78,74 @@ func (p Poly) Iterator() LineSegIterator {
 	}
 }
 
+// Segments returns an iterator over the edges of the receiving polygon,
+// including the edge from the last vertex back to the first, for use with
+// a range statement.
+func (p Poly) Segments() iter.Seq[LineSeg] {
+	return func(yield func(LineSeg) bool) {
+		it := p.Iterator()
+		for it.Next() {
+			if !yield(it.LineSeg()) {
+				return
+			}
+		}
+	}
+}
+
+// Vertices returns an iterator over the vertices of the receiving polygon
+// and their indices, for use with a range statement.
+func (p Poly) Vertices() iter.Seq2[int, Point] {
+	return slices.All(p)
+}
+
+// Winding returns the number of times the polygon winds around the given
+// point, which is positive for anti-clockwise turns assuming a Y axis that
+// increases upward. The result is not meaningful for points on the boundary
+// of the polygon.
+func (p Poly) Winding(pt Point) int {
+	w := 0
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		switch {
+		case a.Y <= pt.Y && b.Y > pt.Y:
+			if orient(a, b, pt) > 0 {
+				w++
+			}
+		case a.Y > pt.Y && b.Y <= pt.Y:
+			if orient(a, b, pt) < 0 {
+				w--
+			}
+		}
+	}
+	return w
+}
+
+// onBoundary returns true if the given point lies on one of the polygon's
+// edges.
+func (p Poly) onBoundary(pt Point) bool {
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		if orient(a, b, pt) != 0 {
+			continue
+		}
+		if (Rect{a, b}).Contains(pt) {
+			return true
+		}
+	}
+	return false
+}
+
+// orientation returns a positive value if the polygon is anti-clockwise, a
+// negative value if it is clockwise, and zero if it encloses no area. The
+// sign of the result is exact; see robust.PolyOrient2D.
+func (p Poly) orientation() float64 {
+	return robust.PolyOrient2D(len(p), func(i int) (float64, float64) {
+		return p[i].X, p[i].Y
+	})
+}
+
+// dirArea returns twice the signed area of the polygon, which is negative
+// for anti-clockwise polygons.
 func (p Poly) dirArea() float64 {
 	l := len(p)
 	var s float64
@@ -0,0 +1,134 @@
+package geom
+
+import (
+	"math/big"
+
+	"github.com/apparentlymart/go-geometry/geom/robust"
+)
+
+// PolyOf represents a closed polygon with vertices of type PointOf[T], as
+// described for Poly.
+//
+// Its predicates are exact for all coordinate types, so for example a
+// polygon with integer coordinates reports exactly which integer points it
+// contains. Other operations, including combining overlapping polygons
+// into a Region with NewRegion, are available only for Poly, and so a PolyOf
+// must be converted with method Float64 to use them.
+type PolyOf[T Coord] []PointOf[T]
+
+// PolyFrom converts a Poly to a PolyOf with the given coordinate type,
+// converting each vertex as described for PointFrom.
+func PolyFrom[T Coord](p Poly) PolyOf[T] {
+	return PolyOf[T](PointsFrom[T](p))
+}
+
+// Float64 returns the Poly with the same vertices as the receiver, converted
+// as described for PointOf.Float64.
+func (p PolyOf[T]) Float64() Poly {
+	return Poly(PointsFloat64(p))
+}
+
+// Contains returns true if the given point is inside the polygon or on its
+// boundary, as described for Poly.Contains.
+func (p PolyOf[T]) Contains(pt PointOf[T]) bool {
+	return p.onBoundary(pt) || p.Winding(pt) != 0
+}
+
+// Facing returns either 1 or -1 depending on the ordering of the points, as
+// described for Poly.Facing.
+func (p PolyOf[T]) Facing() int {
+	if p.orientation() > 0 {
+		return -1
+	}
+	return 1
+}
+
+// Winding returns the number of times the polygon winds around the given
+// point, as described for Poly.Winding.
+func (p PolyOf[T]) Winding(pt PointOf[T]) int {
+	w := 0
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		switch {
+		case a.Y <= pt.Y && b.Y > pt.Y:
+			if Orient(a, b, pt) > 0 {
+				w++
+			}
+		case a.Y > pt.Y && b.Y <= pt.Y:
+			if Orient(a, b, pt) < 0 {
+				w--
+			}
+		}
+	}
+	return w
+}
+
+// onBoundary returns true if the given point lies on one of the polygon's
+// edges.
+func (p PolyOf[T]) onBoundary(pt PointOf[T]) bool {
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		if Orient(a, b, pt) != 0 {
+			continue
+		}
+		if min(a.X, b.X) <= pt.X && pt.X <= max(a.X, b.X) &&
+			min(a.Y, b.Y) <= pt.Y && pt.Y <= max(a.Y, b.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+// orientation returns 1 if the polygon is anti-clockwise, -1 if it is
+// clockwise, and zero if it encloses no area.
+func (p PolyOf[T]) orientation() int {
+	for _, pt := range p {
+		if !exactFloat64(pt) {
+			return p.orientationBig()
+		}
+	}
+	o := robust.PolyOrient2D(len(p), func(i int) (float64, float64) {
+		return float64(p[i].X), float64(p[i].Y)
+	})
+	switch {
+	case o > 0:
+		return 1
+	case o < 0:
+		return -1
+	}
+	return 0
+}
+
+// orientationBig is the implementation of orientation for integer
+// coordinates that can't be converted to float64 exactly, summing the
+// cross products of the edges using arbitrary precision arithmetic.
+func (p PolyOf[T]) orientationBig() int {
+	sum := new(big.Int)
+	var ax, by, bx, ay big.Int
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		ax.SetInt64(int64(a.X))
+		by.SetInt64(int64(b.Y))
+		bx.SetInt64(int64(b.X))
+		ay.SetInt64(int64(a.Y))
+		sum.Add(sum, ax.Mul(&ax, &by))
+		sum.Sub(sum, bx.Mul(&bx, &ay))
+	}
+	return sum.Sign()
+}
+
+// LineSegSeqOf represents a sequence of connected line segments with points
+// of type PointOf[T], as described for LineSegSeq.
+type LineSegSeqOf[T Coord] []PointOf[T]
+
+// LineSegSeqFrom converts a LineSegSeq to a LineSegSeqOf with the given
+// coordinate type, converting each point as described for PointFrom.
+func LineSegSeqFrom[T Coord](s LineSegSeq) LineSegSeqOf[T] {
+	return LineSegSeqOf[T](PointsFrom[T](s))
+}
+
+// Float64 returns the LineSegSeq with the same points as the receiver,
+// converted as described for PointOf.Float64.
+func (s LineSegSeqOf[T]) Float64() LineSegSeq {
+	return LineSegSeq(PointsFloat64(s))
+}
@@ -0,0 +1,83 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPolyOfConversions(t *testing.T) {
+	poly := Poly{{0.4, 0}, {10, 0.6}, {5.5, 8}}
+	for _, problem := range deep.Equal(PolyFrom[int32](poly), PolyOf[int32]{{0, 0}, {10, 1}, {6, 8}}) {
+		t.Error(problem)
+	}
+	for _, problem := range deep.Equal(PolyFrom[float32](poly).Float64(), Poly{{float64(float32(0.4)), 0}, {10, float64(float32(0.6))}, {5.5, 8}}) {
+		t.Error(problem)
+	}
+
+	seq := LineSegSeq{{-1.5, 2}, {3, 4.25}}
+	for _, problem := range deep.Equal(LineSegSeqFrom[int64](seq), LineSegSeqOf[int64]{{-2, 2}, {3, 4}}) {
+		t.Error(problem)
+	}
+	for _, problem := range deep.Equal(LineSegSeqFrom[int64](seq).Float64(), LineSegSeq{{-2, 2}, {3, 4}}) {
+		t.Error(problem)
+	}
+}
+
+func TestPolyOfContains(t *testing.T) {
+	const max = math.MaxInt64
+
+	// A triangle whose edges are far too long for float64 to represent the
+	// points beside them, so only an exact test can tell them apart.
+	tri := PolyOf[int64]{{-max, -max}, {max, -max}, {max, max}}
+
+	tests := []struct {
+		Point PointOf[int64]
+		Want  bool
+	}{
+		{PointOf[int64]{0, 0}, true},
+		{PointOf[int64]{1, 0}, true},
+		{PointOf[int64]{0, 1}, false},
+		{PointOf[int64]{max - 1, max - 2}, true},
+		{PointOf[int64]{max - 2, max - 1}, false},
+		{PointOf[int64]{max, max}, true},
+		{PointOf[int64]{-max, max}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Point), func(t *testing.T) {
+			if got := tri.Contains(test.Point); got != test.Want {
+				t.Errorf("wrong result %t; want %t", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestPolyOfFacing(t *testing.T) {
+	const max = math.MaxInt64
+
+	tests := []struct {
+		Poly interface{ Facing() int }
+		Want int
+	}{
+		{PolyOf[int32]{{0, 0}, {4, 0}, {4, 4}}, -1},
+		{PolyOf[int32]{{0, 0}, {4, 4}, {4, 0}}, 1},
+		{PolyOf[int32]{{0, 0}, {4, 4}, {8, 8}}, 1},
+		{PolyOf[float32]{{1e6, 1e6}, {1e6 + 0.125, 1e6}, {0, 0}}, 1},
+
+		// These enclose an area of one half, which is tiny compared to
+		// their coordinates.
+		{PolyOf[int64]{{max - 2, max - 1}, {max - 1, max - 1}, {max, max}}, -1},
+		{PolyOf[int64]{{max - 2, max - 1}, {max, max}, {max - 1, max - 1}}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Poly), func(t *testing.T) {
+			if got := test.Poly.Facing(); got != test.Want {
+				t.Errorf("wrong result %d; want %d", got, test.Want)
+			}
+		})
+	}
+}
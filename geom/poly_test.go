@@ -0,0 +1,90 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPolyArea(t *testing.T) {
+	tests := []struct {
+		Poly Poly
+		Want float64
+	}{
+		{
+			Poly{{0, 0}, {2, 0}, {2, 3}, {0, 3}},
+			6,
+		},
+		{
+			Poly{{0, 0}, {0, 3}, {2, 3}, {2, 0}},
+			6,
+		},
+		{
+			Poly{{0, 0}, {4, 0}, {0, 2}},
+			4,
+		},
+		{
+			// A figure-eight, whose two halves cancel out.
+			Poly{{0, 0}, {2, 0}, {0, 2}, {2, 2}},
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Poly), func(t *testing.T) {
+			if got := test.Poly.Area(); got != test.Want {
+				t.Errorf("wrong area %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestPolyContains(t *testing.T) {
+	// A "U" shape, with its opening at the top.
+	u := Poly{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}
+
+	tests := []struct {
+		Point Point
+		Want  bool
+	}{
+		{Point{0.5, 0.5}, true},
+		{Point{0.5, 2.5}, true},
+		{Point{1.5, 2}, false},
+		{Point{1.5, 1}, true}, // on the boundary
+		{Point{3, 3}, true},   // at a vertex
+		{Point{-1, 0}, false},
+		{Point{4, 2}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Point), func(t *testing.T) {
+			if got := u.Contains(test.Point); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestPolyWinding(t *testing.T) {
+	// A square wound anti-clockwise twice, and a clockwise square.
+	twice := Poly{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	cw := Poly{{0, 0}, {0, 2}, {2, 2}, {2, 0}}
+
+	tests := []struct {
+		Poly  Poly
+		Point Point
+		Want  int
+	}{
+		{twice, Point{1, 1}, 2},
+		{twice, Point{3, 1}, 0},
+		{cw, Point{1, 1}, -1},
+		{cw, Point{1, -1}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v", test.Poly, test.Point), func(t *testing.T) {
+			if got := test.Poly.Winding(test.Point); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
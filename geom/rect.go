@@ -1,6 +1,20 @@
 package geom
 
+import (
+	"math"
+)
+
 // Rect represents a rectangle as a pair of points.
+//
+// The two points may be any pair of opposite corners of the rectangle, but
+// a rectangle is said to be normalized if its first point is its minimum
+// corner and its second point is its maximum corner. Except where noted,
+// the methods of Rect accept rectangles that are not normalized and return
+// normalized rectangles.
+//
+// A rectangle with zero width or height is empty, but still describes a
+// line segment or point that may intersect or be contained by other
+// rectangles.
 type Rect [2]Point
 
 // ZeroRect is the zero value of Rect, representing a zero-sized rectangle
@@ -22,15 +36,11 @@ func (r Rect) Size() Point {
 	return Point{r[1].X - r[0].X, r[1].Y - r[0].Y}
 }
 
-// Area returns the area of the rectangle. The area is always positive, even
+// Area returns the area of the rectangle. The area is never negative, even
 // if the points of the rectangle are not normalized.
 func (r Rect) Area() float64 {
 	s := r.Size()
-	a := s.X * s.Y
-	if a < 0 {
-		a *= -0.5
-	}
-	return a * 0.5
+	return math.Abs(s.X * s.Y)
 }
 
 // Normalize returns an equivalent rectangle where both dimensions of the
@@ -55,3 +65,146 @@ func (r Rect) Poly() Poly {
 		Point{r[0].X, r[1].Y},
 	}
 }
+
+// Bounds returns the normalized equivalent of the receiver. This method is
+// present only to implement Bounder.
+func (r Rect) Bounds() Rect {
+	return r.Normalize()
+}
+
+// A Bounder is a shape that can report the smallest rectangle containing
+// it.
+type Bounder interface {
+	// Bounds returns the smallest normalized rectangle containing the
+	// receiver.
+	Bounds() Rect
+}
+
+// BoundingRect returns the smallest normalized rectangle containing all of
+// the given points, or ZeroRect if there are none.
+func BoundingRect(pts []Point) Rect {
+	if len(pts) == 0 {
+		return ZeroRect
+	}
+	r := Rect{pts[0], pts[0]}
+	for _, p := range pts[1:] {
+		r[0].X, r[0].Y = math.Min(r[0].X, p.X), math.Min(r[0].Y, p.Y)
+		r[1].X, r[1].Y = math.Max(r[1].X, p.X), math.Max(r[1].Y, p.Y)
+	}
+	return r
+}
+
+// Min returns the corner of the rectangle with the smallest coordinates.
+func (r Rect) Min() Point {
+	return Point{math.Min(r[0].X, r[1].X), math.Min(r[0].Y, r[1].Y)}
+}
+
+// Max returns the corner of the rectangle with the largest coordinates.
+func (r Rect) Max() Point {
+	return Point{math.Max(r[0].X, r[1].X), math.Max(r[0].Y, r[1].Y)}
+}
+
+// Center returns the point at the center of the rectangle.
+func (r Rect) Center() Point {
+	return Point{(r[0].X + r[1].X) * 0.5, (r[0].Y + r[1].Y) * 0.5}
+}
+
+// Empty returns true if the rectangle has zero width or zero height, and
+// so encloses no area.
+func (r Rect) Empty() bool {
+	return r[0].X == r[1].X || r[0].Y == r[1].Y
+}
+
+// Contains returns true if the given point is inside the rectangle or on
+// its edges.
+func (r Rect) Contains(p Point) bool {
+	min, max := r.Min(), r.Max()
+	return min.X <= p.X && p.X <= max.X && min.Y <= p.Y && p.Y <= max.Y
+}
+
+// Dist returns the distance from the given point to the nearest point
+// inside the rectangle or on its edges, which is zero if the rectangle
+// contains the point.
+func (r Rect) Dist(p Point) float64 {
+	min, max := r.Min(), r.Max()
+	dx := math.Max(0, math.Max(min.X-p.X, p.X-max.X))
+	dy := math.Max(0, math.Max(min.Y-p.Y, p.Y-max.Y))
+	return math.Hypot(dx, dy)
+}
+
+// ContainsRect returns true if the given rectangle is entirely inside the
+// receiver, including if their edges touch.
+func (r Rect) ContainsRect(o Rect) bool {
+	return r.Contains(o[0]) && r.Contains(o[1])
+}
+
+// Overlaps returns true if the receiver and the given rectangle have at
+// least one point in common, including if they just touch at their edges.
+func (r Rect) Overlaps(o Rect) bool {
+	rMin, rMax := r.Min(), r.Max()
+	oMin, oMax := o.Min(), o.Max()
+	return rMin.X <= oMax.X && oMin.X <= rMax.X && rMin.Y <= oMax.Y && oMin.Y <= rMax.Y
+}
+
+// Union returns the smallest rectangle containing both the receiver and the
+// given rectangle.
+//
+// Empty rectangles are included in the result just like any other, so for
+// example the union of ZeroRect with another rectangle always contains the
+// origin.
+func (r Rect) Union(o Rect) Rect {
+	rMin, rMax := r.Min(), r.Max()
+	oMin, oMax := o.Min(), o.Max()
+	return Rect{
+		{math.Min(rMin.X, oMin.X), math.Min(rMin.Y, oMin.Y)},
+		{math.Max(rMax.X, oMax.X), math.Max(rMax.Y, oMax.Y)},
+	}
+}
+
+// Intersect returns the rectangle that the receiver and the given rectangle
+// have in common. The second result is false if they have no points in
+// common, as reported by Overlaps, in which case the first is ZeroRect.
+//
+// If the rectangles only touch at their edges then the result is an empty
+// rectangle along the edges they share.
+func (r Rect) Intersect(o Rect) (Rect, bool) {
+	if !r.Overlaps(o) {
+		return ZeroRect, false
+	}
+	rMin, rMax := r.Min(), r.Max()
+	oMin, oMax := o.Min(), o.Max()
+	return Rect{
+		{math.Max(rMin.X, oMin.X), math.Max(rMin.Y, oMin.Y)},
+		{math.Min(rMax.X, oMax.X), math.Min(rMax.Y, oMax.Y)},
+	}, true
+}
+
+// Inset returns a rectangle whose edges have each been moved inwards by the
+// given distance, or outwards if the distance is negative.
+//
+// If the rectangle is narrower than twice the distance in either dimension
+// then it collapses to its center in that dimension.
+func (r Rect) Inset(d float64) Rect {
+	min, max := r.Min(), r.Max()
+	c := r.Center()
+	min.X, max.X = math.Min(min.X+d, c.X), math.Max(max.X-d, c.X)
+	min.Y, max.Y = math.Min(min.Y+d, c.Y), math.Max(max.Y-d, c.Y)
+	return Rect{min, max}
+}
+
+// Outset returns a rectangle whose edges have each been moved outwards by
+// the given distance. It is equivalent to Inset with the distance negated.
+func (r Rect) Outset(d float64) Rect {
+	return r.Inset(-d)
+}
+
+// ScaleAbout returns a rectangle that has been scaled by the given factor
+// in both dimensions, keeping the given point in the same position.
+//
+// A negative factor also reflects the rectangle through that point.
+func (r Rect) ScaleAbout(p Point, factor float64) Rect {
+	scale := func(q Point) Point {
+		return p.Add(q.Sub(p).Scale(factor))
+	}
+	return Rect{scale(r[0]), scale(r[1])}.Normalize()
+}
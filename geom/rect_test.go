@@ -0,0 +1,245 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestRectArea(t *testing.T) {
+	tests := []struct {
+		Rect Rect
+		Want float64
+	}{
+		{Rect{{0, 0}, {2, 3}}, 6},
+		{Rect{{2, 3}, {0, 0}}, 6},
+		{Rect{{2, 0}, {0, 3}}, 6},
+		{Rect{{1, 1}, {1, 5}}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Rect), func(t *testing.T) {
+			if got := test.Rect.Area(); got != test.Want {
+				t.Errorf("wrong area %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestRectUnionIntersect(t *testing.T) {
+	tests := []struct {
+		A, B          Rect
+		WantUnion     Rect
+		WantIntersect Rect
+		WantOverlaps  bool
+	}{
+		{
+			Rect{{0, 0}, {4, 4}},
+			Rect{{2, 2}, {6, 6}},
+			Rect{{0, 0}, {6, 6}},
+			Rect{{2, 2}, {4, 4}},
+			true,
+		},
+		{
+			// Not normalized
+			Rect{{4, 4}, {0, 0}},
+			Rect{{6, 2}, {2, 6}},
+			Rect{{0, 0}, {6, 6}},
+			Rect{{2, 2}, {4, 4}},
+			true,
+		},
+		{
+			// Touching along an edge
+			Rect{{0, 0}, {2, 2}},
+			Rect{{2, 1}, {3, 5}},
+			Rect{{0, 0}, {3, 5}},
+			Rect{{2, 1}, {2, 2}},
+			true,
+		},
+		{
+			// Disjoint
+			Rect{{0, 0}, {1, 1}},
+			Rect{{2, 2}, {3, 3}},
+			Rect{{0, 0}, {3, 3}},
+			ZeroRect,
+			false,
+		},
+		{
+			// One inside the other
+			Rect{{0, 0}, {10, 10}},
+			Rect{{2, 2}, {3, 3}},
+			Rect{{0, 0}, {10, 10}},
+			Rect{{2, 2}, {3, 3}},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v", test.A, test.B), func(t *testing.T) {
+			for _, problem := range deep.Equal(test.A.Union(test.B), test.WantUnion) {
+				t.Errorf("Union: %s", problem)
+			}
+			got, ok := test.A.Intersect(test.B)
+			for _, problem := range deep.Equal(got, test.WantIntersect) {
+				t.Errorf("Intersect: %s", problem)
+			}
+			if ok != test.WantOverlaps {
+				t.Errorf("Intersect returned %#v; want %#v", ok, test.WantOverlaps)
+			}
+			if got := test.A.Overlaps(test.B); got != test.WantOverlaps {
+				t.Errorf("Overlaps returned %#v; want %#v", got, test.WantOverlaps)
+			}
+			if got := test.B.Overlaps(test.A); got != test.WantOverlaps {
+				t.Errorf("reversed Overlaps returned %#v; want %#v", got, test.WantOverlaps)
+			}
+		})
+	}
+}
+
+func TestRectContains(t *testing.T) {
+	r := Rect{{4, 4}, {0, 0}}
+
+	points := []struct {
+		Point Point
+		Want  bool
+	}{
+		{Point{2, 2}, true},
+		{Point{0, 4}, true},
+		{Point{4, 2}, true},
+		{Point{5, 2}, false},
+		{Point{2, -1}, false},
+	}
+	for _, test := range points {
+		t.Run(fmt.Sprintf("%#v", test.Point), func(t *testing.T) {
+			if got := r.Contains(test.Point); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+
+	rects := []struct {
+		Rect Rect
+		Want bool
+	}{
+		{Rect{{1, 1}, {3, 3}}, true},
+		{Rect{{4, 4}, {0, 0}}, true},
+		{Rect{{1, 1}, {1, 1}}, true},
+		{Rect{{1, 1}, {5, 3}}, false},
+		{Rect{{5, 5}, {6, 6}}, false},
+	}
+	for _, test := range rects {
+		t.Run(fmt.Sprintf("%#v", test.Rect), func(t *testing.T) {
+			if got := r.ContainsRect(test.Rect); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestRectDist(t *testing.T) {
+	r := Rect{{4, 4}, {0, 0}}
+
+	tests := []struct {
+		Point Point
+		Want  float64
+	}{
+		{Point{2, 2}, 0},
+		{Point{4, 2}, 0},
+		{Point{6, 2}, 2},
+		{Point{2, -3}, 3},
+		{Point{7, 8}, 5},
+		{Point{-3, -4}, 5},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Point), func(t *testing.T) {
+			if got := r.Dist(test.Point); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestRectAccessors(t *testing.T) {
+	r := Rect{{4, 1}, {0, 3}}
+
+	for _, problem := range deep.Equal(r.Min(), Point{0, 1}) {
+		t.Errorf("Min: %s", problem)
+	}
+	for _, problem := range deep.Equal(r.Max(), Point{4, 3}) {
+		t.Errorf("Max: %s", problem)
+	}
+	for _, problem := range deep.Equal(r.Center(), Point{2, 2}) {
+		t.Errorf("Center: %s", problem)
+	}
+	if r.Empty() {
+		t.Errorf("%#v is empty", r)
+	}
+	if empty := (Rect{{1, 0}, {1, 5}}); !empty.Empty() {
+		t.Errorf("%#v is not empty", empty)
+	}
+}
+
+func TestRectInset(t *testing.T) {
+	tests := []struct {
+		Rect Rect
+		D    float64
+		Want Rect
+	}{
+		{Rect{{0, 0}, {10, 4}}, 1, Rect{{1, 1}, {9, 3}}},
+		{Rect{{10, 4}, {0, 0}}, 1, Rect{{1, 1}, {9, 3}}},
+		{Rect{{0, 0}, {10, 4}}, -1, Rect{{-1, -1}, {11, 5}}},
+		{Rect{{0, 0}, {10, 4}}, 3, Rect{{3, 2}, {7, 2}}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v by %#v", test.Rect, test.D), func(t *testing.T) {
+			for _, problem := range deep.Equal(test.Rect.Inset(test.D), test.Want) {
+				t.Error(problem)
+			}
+			for _, problem := range deep.Equal(test.Rect.Outset(-test.D), test.Want) {
+				t.Errorf("Outset: %s", problem)
+			}
+		})
+	}
+}
+
+func TestRectScaleAbout(t *testing.T) {
+	tests := []struct {
+		Rect   Rect
+		Center Point
+		Factor float64
+		Want   Rect
+	}{
+		{Rect{{0, 0}, {2, 2}}, Point{1, 1}, 2, Rect{{-1, -1}, {3, 3}}},
+		{Rect{{0, 0}, {2, 2}}, Point{0, 0}, 3, Rect{{0, 0}, {6, 6}}},
+		{Rect{{0, 0}, {2, 2}}, Point{0, 0}, -1, Rect{{-2, -2}, {0, 0}}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v about %#v by %#v", test.Rect, test.Center, test.Factor), func(t *testing.T) {
+			for _, problem := range deep.Equal(test.Rect.ScaleAbout(test.Center, test.Factor), test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestBoundingRect(t *testing.T) {
+	tests := []struct {
+		Points []Point
+		Want   Rect
+	}{
+		{nil, ZeroRect},
+		{[]Point{{3, 4}}, Rect{{3, 4}, {3, 4}}},
+		{[]Point{{3, 4}, {-1, 6}, {2, 0}}, Rect{{-1, 0}, {3, 6}}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Points), func(t *testing.T) {
+			for _, problem := range deep.Equal(BoundingRect(test.Points), test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
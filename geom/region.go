@@ -0,0 +1,180 @@
+package geom
+
+// Region represents an area of the plane as a set of separate parts, each
+// of which is bounded by an outer polygon and may have polygonal holes.
+//
+// The parts of a well-formed region don't overlap one another, the holes of
+// each part lie inside its outer polygon, and the holes of a part don't
+// overlap one another. Method Normalize additionally ensures that the outer
+// polygons are anti-clockwise and the holes are clockwise.
+type Region []RegionPart
+
+// RegionPart is a single part of a Region, consisting of an outer polygon
+// and any number of holes inside it.
+type RegionPart struct {
+	Outer Poly
+	Holes []Poly
+}
+
+// FillRule determines which points are inside a shape bounded by several
+// rings, given the number of times those rings wind around each point.
+type FillRule int
+
+const (
+	// NonZero considers a point to be inside if the rings wind around it
+	// a non-zero number of times, taking their directions into account.
+	NonZero FillRule = iota
+
+	// EvenOdd considers a point to be inside if it's enclosed by an odd
+	// number of rings, regardless of their directions.
+	EvenOdd
+)
+
+// Filled returns true if a point with the given winding number is inside
+// a shape under the receiving rule.
+func (r FillRule) Filled(winding int) bool {
+	if r == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// NewRegion builds a region from a set of rings, using the given fill rule
+// to decide which parts of the plane are inside. Each ring that separates
+// an inside area from an outside area becomes either the outer polygon of a
+// part or a hole in the nearest part enclosing it. Other rings, and rings
+// that enclose no area, are discarded.
+//
+// The rings must each be simple and must not cross one another, although
+// they may be nested to any depth. The result is normalized, as described
+// for method Normalize.
+func NewRegion(rings []Poly, rule FillRule) Region {
+	tree := NestRings(rings)
+	roles := tree.Roles(rule)
+
+	var ret Region
+	part := make(map[int]int)
+	for i, ring := range rings {
+		if roles[i] == OuterRing {
+			part[i] = len(ret)
+			ret = append(ret, RegionPart{Outer: ring.Poly()})
+		}
+	}
+	for i, ring := range rings {
+		if roles[i] != HoleRing {
+			continue
+		}
+		owner := tree.Nodes[i].Parent
+		for owner >= 0 && roles[owner] != OuterRing {
+			owner = tree.Nodes[owner].Parent
+		}
+		if owner < 0 {
+			continue // can't happen for rings that don't cross
+		}
+		rp := &ret[part[owner]]
+		rp.Holes = append(rp.Holes, ring.Poly())
+	}
+	return ret.Normalize()
+}
+
+// Area returns the total area of the region.
+func (r Region) Area() float64 {
+	var a float64
+	for _, part := range r {
+		a += part.Area()
+	}
+	return a
+}
+
+// Bounds returns the smallest normalized rectangle that contains the whole
+// region, or ZeroRect if the region is empty.
+func (r Region) Bounds() Rect {
+	if len(r) == 0 {
+		return ZeroRect
+	}
+	b := r[0].Bounds()
+	for _, part := range r[1:] {
+		b = b.Union(part.Bounds())
+	}
+	return b
+}
+
+// Contains returns true if the given point is inside the region, including
+// on its boundary.
+func (r Region) Contains(pt Point) bool {
+	for _, part := range r {
+		if part.Contains(pt) {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns a copy of the receiver whose outer polygons are all
+// anti-clockwise and whose holes are all clockwise, assuming a Y axis that
+// increases upward.
+func (r Region) Normalize() Region {
+	if r == nil {
+		return nil
+	}
+	ret := make(Region, len(r))
+	for i, part := range r {
+		ret[i] = part.Normalize()
+	}
+	return ret
+}
+
+// Area returns the area of the part, which is the area of its outer polygon
+// less the areas of its holes.
+func (rp RegionPart) Area() float64 {
+	a := rp.Outer.Area()
+	for _, h := range rp.Holes {
+		a -= h.Area()
+	}
+	return a
+}
+
+// Bounds returns the smallest normalized rectangle that contains the part.
+func (rp RegionPart) Bounds() Rect {
+	return rp.Outer.Bounds()
+}
+
+// Contains returns true if the given point is inside the part's outer
+// polygon but not strictly inside any of its holes.
+func (rp RegionPart) Contains(pt Point) bool {
+	if !rp.Outer.Contains(pt) {
+		return false
+	}
+	for _, h := range rp.Holes {
+		if !h.onBoundary(pt) && h.Winding(pt) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalize returns a copy of the receiver whose outer polygon is
+// anti-clockwise and whose holes are clockwise, assuming a Y axis that
+// increases upward.
+func (rp RegionPart) Normalize() RegionPart {
+	ret := RegionPart{Outer: rp.Outer.Poly()}
+	if ret.Outer.Facing() != -1 {
+		reversePoints(ret.Outer)
+	}
+	if rp.Holes != nil {
+		ret.Holes = make([]Poly, len(rp.Holes))
+		for i, h := range rp.Holes {
+			ret.Holes[i] = h.Poly()
+			if ret.Holes[i].Facing() != 1 {
+				reversePoints(ret.Holes[i])
+			}
+		}
+	}
+	return ret
+}
+
+func reversePoints(pts []Point) {
+	for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+		pts[i], pts[j] = pts[j], pts[i]
+	}
+}
@@ -0,0 +1,129 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestNewRegion(t *testing.T) {
+	square := func(min, max float64, facing int) Poly {
+		p := Rect{{min, min}, {max, max}}.Poly() // anti-clockwise
+		if facing == 1 {
+			reversePoints(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		Name  string
+		Rings []Poly
+		Rule  FillRule
+		Want  Region
+	}{
+		{
+			"empty",
+			nil,
+			NonZero,
+			nil,
+		},
+		{
+			"single clockwise ring",
+			[]Poly{square(0, 10, 1)},
+			NonZero,
+			Region{{Outer: square(0, 10, -1)}},
+		},
+		{
+			"opposing rings under nonzero",
+			[]Poly{square(2, 8, 1), square(0, 10, -1)},
+			NonZero,
+			Region{{Outer: square(0, 10, -1), Holes: []Poly{square(2, 8, 1)}}},
+		},
+		{
+			"same-direction rings under nonzero",
+			[]Poly{square(0, 10, -1), square(2, 8, -1)},
+			NonZero,
+			Region{{Outer: square(0, 10, -1)}},
+		},
+		{
+			"same-direction rings under even-odd",
+			[]Poly{square(0, 10, -1), square(2, 8, -1)},
+			EvenOdd,
+			Region{{Outer: square(0, 10, -1), Holes: []Poly{square(2, 8, 1)}}},
+		},
+		{
+			"island in a hole",
+			[]Poly{square(0, 10, -1), square(2, 8, -1), square(4, 6, -1)},
+			EvenOdd,
+			Region{
+				{Outer: square(0, 10, -1), Holes: []Poly{square(2, 8, 1)}},
+				{Outer: square(4, 6, -1)},
+			},
+		},
+		{
+			"degenerate ring",
+			[]Poly{{{0, 0}, {1, 1}, {2, 2}}, square(0, 10, -1)},
+			NonZero,
+			Region{{Outer: square(0, 10, -1)}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got := NewRegion(test.Rings, test.Rule)
+
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestRegion(t *testing.T) {
+	r := Region{
+		{
+			Outer: Poly{{0, 0}, {0, 10}, {10, 10}, {10, 0}},
+			Holes: []Poly{{{2, 2}, {8, 2}, {8, 8}, {2, 8}}},
+		},
+		{
+			Outer: Poly{{4, 4}, {6, 4}, {6, 6}, {4, 6}},
+		},
+	}
+
+	if got, want := r.Area(), 100.0-36.0+4.0; got != want {
+		t.Errorf("wrong area %#v; want %#v", got, want)
+	}
+	if got, want := r.Bounds(), (Rect{{0, 0}, {10, 10}}); got != want {
+		t.Errorf("wrong bounds %#v; want %#v", got, want)
+	}
+
+	contains := []struct {
+		Point Point
+		Want  bool
+	}{
+		{Point{1, 1}, true},
+		{Point{3, 3}, false},
+		{Point{2, 5}, true}, // on the boundary of the hole
+		{Point{5, 5}, true},
+		{Point{11, 5}, false},
+	}
+	for _, test := range contains {
+		t.Run(fmt.Sprintf("%#v", test.Point), func(t *testing.T) {
+			if got := r.Contains(test.Point); got != test.Want {
+				t.Errorf("wrong result %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+
+	norm := r.Normalize()
+	if got := norm[0].Outer.Facing(); got != -1 {
+		t.Errorf("outer has facing %d after normalizing; want -1", got)
+	}
+	if got := norm[0].Holes[0].Facing(); got != 1 {
+		t.Errorf("hole has facing %d after normalizing; want 1", got)
+	}
+	if got := r[0].Outer.Facing(); got != 1 {
+		t.Errorf("normalizing modified the receiver")
+	}
+}
@@ -0,0 +1,166 @@
+package geom
+
+import (
+	"sort"
+)
+
+// RingTree describes how a set of rings are nested inside one another, such
+// as the contours of a font glyph or the sub-paths of an SVG path.
+type RingTree struct {
+	// Nodes has one element for each of the rings the tree was built from,
+	// in the same order.
+	Nodes []RingNode
+
+	// Roots are the indices of the rings that aren't inside any other ring,
+	// in increasing order.
+	Roots []int
+}
+
+// RingNode describes the position of a single ring within a RingTree.
+type RingNode struct {
+	// Parent is the index of the smallest ring that encloses this one, or -1
+	// if there is no such ring.
+	Parent int
+
+	// Children are the indices of the rings whose parent is this one, in
+	// increasing order.
+	Children []int
+
+	// Depth is the number of rings enclosing this one.
+	Depth int
+
+	// Winding is the number of times that this ring and the rings enclosing
+	// it wind around the points just inside this ring, where anti-clockwise
+	// rings count as positive assuming a Y axis that increases upward.
+	// The winding number just outside the ring is that of its parent, or
+	// zero for a root.
+	Winding int
+}
+
+// RingRole describes the part a ring plays in a shape.
+type RingRole int
+
+const (
+	// NotBoundary is the role of a ring that doesn't separate the inside of
+	// the shape from the outside, because the points on either side of it
+	// are both inside or both outside. Rings that enclose no area also have
+	// this role.
+	NotBoundary RingRole = iota
+
+	// OuterRing is the role of a ring that has the inside of the shape
+	// within it and the outside of the shape around it.
+	OuterRing
+
+	// HoleRing is the role of a ring that has the outside of the shape
+	// within it and the inside of the shape around it, such as the counter
+	// of a letter "o".
+	HoleRing
+)
+
+// NestRings builds the tree describing how the given rings are nested.
+//
+// The rings must each be simple and must not cross one another, although
+// they may touch. A ring that encloses no area is always a root with no
+// children.
+func NestRings(rings []Poly) *RingTree {
+	areas := make([]float64, len(rings))
+	bounds := make([]Rect, len(rings))
+	for i, ring := range rings {
+		areas[i] = ring.Area()
+		bounds[i] = ring.Bounds()
+	}
+
+	// Processing rings from largest to smallest means that each ring's
+	// possible containers have already been placed, and so each ring's
+	// winding number can be found from its parent's.
+	order := make([]int, len(rings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return areas[order[a]] > areas[order[b]]
+	})
+
+	tree := &RingTree{
+		Nodes: make([]RingNode, len(rings)),
+	}
+	for k, i := range order {
+		node := &tree.Nodes[i]
+		node.Parent = -1
+		if areas[i] == 0 {
+			continue
+		}
+		for _, j := range order[:k] {
+			if areas[j] == 0 || !bounds[j].ContainsRect(bounds[i]) {
+				continue
+			}
+			// The last container found is the smallest, since they are
+			// visited in decreasing order of area.
+			if ringInside(rings[i], rings[j]) {
+				node.Parent = j
+			}
+		}
+		node.Winding = -rings[i].Facing()
+		if node.Parent >= 0 {
+			parent := &tree.Nodes[node.Parent]
+			node.Depth = parent.Depth + 1
+			node.Winding += parent.Winding
+		}
+	}
+
+	for i, node := range tree.Nodes {
+		if node.Parent < 0 {
+			tree.Roots = append(tree.Roots, i)
+			continue
+		}
+		parent := &tree.Nodes[node.Parent]
+		parent.Children = append(parent.Children, i)
+	}
+	return tree
+}
+
+// Roles returns the role of each ring in the tree, in the same order as the
+// rings were given, when the inside of the shape is decided using the given
+// fill rule.
+//
+// Each hole is inside an outer ring, and the nearest such ring among its
+// ancestors is the outer boundary of the part of the shape containing the
+// hole.
+func (t *RingTree) Roles(rule FillRule) []RingRole {
+	ret := make([]RingRole, len(t.Nodes))
+	for i, node := range t.Nodes {
+		// Rings that enclose no area have the same winding number as their
+		// surroundings, and so are never boundaries.
+		inside := node.Winding
+		outside := 0
+		if node.Parent >= 0 {
+			outside = t.Nodes[node.Parent].Winding
+		}
+		switch {
+		case rule.Filled(inside) && !rule.Filled(outside):
+			ret[i] = OuterRing
+		case !rule.Filled(inside) && rule.Filled(outside):
+			ret[i] = HoleRing
+		}
+	}
+	return ret
+}
+
+// ringInside returns true if ring a is inside ring b, assuming that the two
+// don't cross and that a is no larger than b.
+func ringInside(a, b Poly) bool {
+	for _, v := range a {
+		if !b.onBoundary(v) {
+			return b.Winding(v) != 0
+		}
+	}
+	// All of a's vertices are on b's boundary, so we'll test instead the
+	// midpoint of an edge that doesn't run along it.
+	for i, v := range a {
+		m := v.Add(a[(i+1)%len(a)]).Scale(0.5)
+		if !b.onBoundary(m) {
+			return b.Winding(m) != 0
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestNestRings(t *testing.T) {
+	// Rings in the style of a letter "B" with its two counters, plus a
+	// separate dot and a zero-area ring. The counters run in the opposite
+	// direction to the outer rings.
+	rings := []Poly{
+		{{1, 1}, {1, 4}, {4, 4}, {4, 1}},   // lower counter
+		{{0, 0}, {5, 0}, {5, 10}, {0, 10}}, // outer
+		{{1, 6}, {1, 9}, {4, 9}, {4, 6}},   // upper counter
+		{{6, 0}, {7, 0}, {7, 1}, {6, 1}},   // dot
+		{{2, 2}, {3, 2}, {3, 3}, {2, 3}},   // island in lower counter
+		{{8, 8}, {9, 9}, {10, 10}},         // no area
+	}
+
+	got := NestRings(rings)
+	want := &RingTree{
+		Nodes: []RingNode{
+			{Parent: 1, Children: []int{4}, Depth: 1, Winding: 0},
+			{Parent: -1, Children: []int{0, 2}, Depth: 0, Winding: 1},
+			{Parent: 1, Depth: 1, Winding: 0},
+			{Parent: -1, Depth: 0, Winding: 1},
+			{Parent: 0, Depth: 2, Winding: 1},
+			{Parent: -1, Depth: 0, Winding: 0},
+		},
+		Roots: []int{1, 3, 5},
+	}
+	for _, problem := range deep.Equal(got, want) {
+		t.Error(problem)
+	}
+
+	tests := []struct {
+		Name string
+		Rule FillRule
+		Want []RingRole
+	}{
+		{
+			"nonzero",
+			NonZero,
+			[]RingRole{HoleRing, OuterRing, HoleRing, OuterRing, OuterRing, NotBoundary},
+		},
+		{
+			"even-odd",
+			EvenOdd,
+			[]RingRole{HoleRing, OuterRing, HoleRing, OuterRing, OuterRing, NotBoundary},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			for _, problem := range deep.Equal(got.Roles(test.Rule), test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestRingTreeRolesSameDirection(t *testing.T) {
+	// When nested rings all run the same direction, only the even-odd rule
+	// treats the inner ring as a hole.
+	tree := NestRings([]Poly{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		{{2, 2}, {8, 2}, {8, 8}, {2, 8}},
+	})
+
+	if got, want := tree.Roles(NonZero), []RingRole{OuterRing, NotBoundary}; !roleSliceEqual(got, want) {
+		t.Errorf("wrong nonzero roles %#v; want %#v", got, want)
+	}
+	if got, want := tree.Roles(EvenOdd), []RingRole{OuterRing, HoleRing}; !roleSliceEqual(got, want) {
+		t.Errorf("wrong even-odd roles %#v; want %#v", got, want)
+	}
+}
+
+func roleSliceEqual(a, b []RingRole) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
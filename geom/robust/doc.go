@@ -0,0 +1,19 @@
+// Package robust provides geometric predicates whose signs are always
+// correct, regardless of rounding errors.
+//
+// The predicates use the adaptive-precision approach described by Jonathan
+// Shewchuk in "Adaptive Precision Floating-Point Arithmetic and Fast Robust
+// Geometric Predicates". Each first computes its result using ordinary
+// floating point arithmetic along with a bound on the error, and falls back
+// on progressively more precise (and more expensive) methods only when the
+// error could affect the sign of the result. Most inputs therefore cost
+// little more than the naive calculation.
+//
+// As in Shewchuk's implementation, the results are exact only if no
+// intermediate value overflows or underflows, which in practice requires
+// that the coordinates be neither extremely large nor extremely close to
+// zero without being zero.
+//
+// The functions here take individual coordinates rather than points, so
+// that they can be used by package geom itself.
+package robust
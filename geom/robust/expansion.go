@@ -0,0 +1,149 @@
+package robust
+
+import (
+	"math"
+)
+
+// An expansion represents a number exactly as the sum of float64
+// components, which are non-overlapping and in order of increasing
+// magnitude. Components that are zero are omitted.
+//
+// The algorithms here are those of Shewchuk's paper, using a fused
+// multiply-add to find the rounding error of a product.
+type expansion []float64
+
+// epsilon is half of the difference between one and the next larger
+// float64, which bounds the relative error of each arithmetic operation.
+const epsilon = 1.0 / (1 << 53)
+
+// twoSum returns a+b as the rounded sum x and its rounding error y, so
+// that x+y is exactly a+b.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	return x, (a - av) + (b - bv)
+}
+
+// fastTwoSum is like twoSum, but requires that |a| >= |b|.
+func fastTwoSum(a, b float64) (x, y float64) {
+	x = a + b
+	return x, b - (x - a)
+}
+
+// twoDiff returns a-b as the rounded difference x and its rounding error
+// y, so that x+y is exactly a-b.
+func twoDiff(a, b float64) (x, y float64) {
+	x = a - b
+	return x, twoDiffTail(a, b, x)
+}
+
+// twoDiffTail returns the rounding error of x, which must be the result of
+// computing a-b.
+func twoDiffTail(a, b, x float64) float64 {
+	bv := a - x
+	av := x + bv
+	return (a - av) + (bv - b)
+}
+
+// twoProduct returns a*b as the rounded product x and its rounding error
+// y, so that x+y is exactly a*b.
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	return x, math.FMA(a, b, -x)
+}
+
+// twoTwoDiff returns (a1+a0) - (b1+b0) as an expansion of four
+// components, which may include zeros.
+func twoTwoDiff(a1, a0, b1, b0 float64) [4]float64 {
+	i, x0 := twoDiff(a0, b0)
+	j, k := twoSum(a1, i)
+	i, x1 := twoDiff(k, b1)
+	x3, x2 := twoSum(j, i)
+	return [4]float64{x0, x1, x2, x3}
+}
+
+// grow returns the sum of the expansion and b.
+func (e expansion) grow(b float64) expansion {
+	ret := make(expansion, 0, len(e)+1)
+	q := b
+	for _, c := range e {
+		var h float64
+		q, h = twoSum(q, c)
+		if h != 0 {
+			ret = append(ret, h)
+		}
+	}
+	if q != 0 || len(ret) == 0 {
+		ret = append(ret, q)
+	}
+	return ret
+}
+
+// add returns the sum of the two expansions.
+func (e expansion) add(f expansion) expansion {
+	for _, c := range f {
+		e = e.grow(c)
+	}
+	return e
+}
+
+// scale returns the product of the expansion and b.
+func (e expansion) scale(b float64) expansion {
+	if len(e) == 0 {
+		return expansion{0}
+	}
+	ret := make(expansion, 0, 2*len(e))
+	q, h := twoProduct(e[0], b)
+	if h != 0 {
+		ret = append(ret, h)
+	}
+	for _, c := range e[1:] {
+		p1, p0 := twoProduct(c, b)
+		sum, h := twoSum(q, p0)
+		if h != 0 {
+			ret = append(ret, h)
+		}
+		q, h = fastTwoSum(p1, sum)
+		if h != 0 {
+			ret = append(ret, h)
+		}
+	}
+	if q != 0 || len(ret) == 0 {
+		ret = append(ret, q)
+	}
+	return ret
+}
+
+// mul returns the product of the two expansions.
+func (e expansion) mul(f expansion) expansion {
+	ret := expansion{0}
+	for _, c := range f {
+		ret = ret.add(e.scale(c))
+	}
+	return ret
+}
+
+// neg returns the negation of the expansion.
+func (e expansion) neg() expansion {
+	ret := make(expansion, len(e))
+	for i, c := range e {
+		ret[i] = -c
+	}
+	return ret
+}
+
+// sign returns the value of the largest component of the expansion, which
+// has the same sign as the expansion as a whole.
+func (e expansion) sign() float64 {
+	if len(e) == 0 {
+		return 0
+	}
+	return e[len(e)-1]
+}
+
+// diff returns a-b as an exact expansion.
+func diff(a, b float64) expansion {
+	x, y := twoDiff(a, b)
+	return expansion{y}.grow(x)
+}
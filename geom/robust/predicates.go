@@ -0,0 +1,190 @@
+package robust
+
+import (
+	"math"
+)
+
+// Error bounds for the stages of each predicate, from Shewchuk's paper.
+const (
+	resultErrBound = (3 + 8*epsilon) * epsilon
+	ccwErrBoundA   = (3 + 16*epsilon) * epsilon
+	ccwErrBoundB   = (2 + 12*epsilon) * epsilon
+	ccwErrBoundC   = (9 + 64*epsilon) * epsilon * epsilon
+	iccErrBoundA   = (10 + 96*epsilon) * epsilon
+)
+
+// Orient2D returns a positive value if the points a, b and c are in
+// anti-clockwise order, a negative value if they are in clockwise order,
+// and zero if they are collinear, assuming a Y axis that increases upward.
+//
+// The result approximates twice the signed area of the triangle with the
+// points as its vertices, but its sign is always exactly correct.
+func Orient2D(ax, ay, bx, by, cx, cy float64) float64 {
+	detLeft := (ax - cx) * (by - cy)
+	detRight := (ay - cy) * (bx - cx)
+	det := detLeft - detRight
+
+	var detSum float64
+	switch {
+	case detLeft > 0:
+		if detRight <= 0 {
+			return det
+		}
+		detSum = detLeft + detRight
+	case detLeft < 0:
+		if detRight >= 0 {
+			return det
+		}
+		detSum = -detLeft - detRight
+	default:
+		return det
+	}
+
+	if errBound := ccwErrBoundA * detSum; det >= errBound || -det >= errBound {
+		return det
+	}
+	return orient2DAdapt(ax, ay, bx, by, cx, cy, detSum)
+}
+
+// orient2DAdapt is the slow path of Orient2D, used when the simple
+// calculation might have the wrong sign.
+func orient2DAdapt(ax, ay, bx, by, cx, cy, detSum float64) float64 {
+	acx, bcx := ax-cx, bx-cx
+	acy, bcy := ay-cy, by-cy
+
+	// First we calculate the determinant exactly for the rounded
+	// differences.
+	l1, l0 := twoProduct(acx, bcy)
+	r1, r0 := twoProduct(acy, bcx)
+	b := twoTwoDiff(l1, l0, r1, r0)
+	det := b[0] + b[1] + b[2] + b[3]
+	if errBound := ccwErrBoundB * detSum; det >= errBound || -det >= errBound {
+		return det
+	}
+
+	// If the differences were exact then so is that result.
+	acxTail := twoDiffTail(ax, cx, acx)
+	bcxTail := twoDiffTail(bx, cx, bcx)
+	acyTail := twoDiffTail(ay, cy, acy)
+	bcyTail := twoDiffTail(by, cy, bcy)
+	if acxTail == 0 && acyTail == 0 && bcxTail == 0 && bcyTail == 0 {
+		return det
+	}
+
+	// Otherwise a first-order correction for the rounding errors in the
+	// differences is usually enough.
+	errBound := ccwErrBoundC*detSum + resultErrBound*math.Abs(det)
+	det += (acx*bcyTail + bcy*acxTail) - (acy*bcxTail + bcx*acyTail)
+	if det >= errBound || -det >= errBound {
+		return det
+	}
+
+	// Failing that, we add in each of the remaining terms exactly.
+	exact := expansion(b[:])
+	for _, t := range [][4]float64{
+		twoProductDiff(acxTail, bcy, acyTail, bcx),
+		twoProductDiff(acx, bcyTail, acy, bcxTail),
+		twoProductDiff(acxTail, bcyTail, acyTail, bcxTail),
+	} {
+		exact = exact.add(t[:])
+	}
+	return exact.sign()
+}
+
+// twoProductDiff returns a*b - c*d as an expansion of four components.
+func twoProductDiff(a, b, c, d float64) [4]float64 {
+	s1, s0 := twoProduct(a, b)
+	t1, t0 := twoProduct(c, d)
+	return twoTwoDiff(s1, s0, t1, t0)
+}
+
+// InCircle returns a positive value if the point d lies inside the circle
+// passing through a, b and c, a negative value if it lies outside, and zero
+// if it lies on the circle. The points a, b and c must be in anti-clockwise
+// order, as determined by Orient2D, or else the sign of the result is
+// reversed.
+//
+// The sign of the result is always exactly correct, but its magnitude is
+// only an approximation of the corresponding determinant.
+func InCircle(ax, ay, bx, by, cx, cy, dx, dy float64) float64 {
+	adx, bdx, cdx := ax-dx, bx-dx, cx-dx
+	ady, bdy, cdy := ay-dy, by-dy, cy-dy
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	aLift := adx*adx + ady*ady
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	bLift := bdx*bdx + bdy*bdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	cLift := cdx*cdx + cdy*cdy
+
+	det := aLift*(bdxcdy-cdxbdy) + bLift*(cdxady-adxcdy) + cLift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*aLift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*bLift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*cLift
+	if errBound := iccErrBoundA * permanent; det > errBound || -det > errBound {
+		return det
+	}
+	return inCircleExact(ax, ay, bx, by, cx, cy, dx, dy)
+}
+
+// inCircleExact is the slow path of InCircle, which evaluates the
+// determinant exactly.
+func inCircleExact(ax, ay, bx, by, cx, cy, dx, dy float64) float64 {
+	adx, ady := diff(ax, dx), diff(ay, dy)
+	bdx, bdy := diff(bx, dx), diff(by, dy)
+	cdx, cdy := diff(cx, dx), diff(cy, dy)
+
+	cross := func(ux, uy, vx, vy expansion) expansion {
+		return ux.mul(vy).add(uy.mul(vx).neg())
+	}
+	lift := func(x, y expansion) expansion {
+		return x.mul(x).add(y.mul(y))
+	}
+
+	det := lift(adx, ady).mul(cross(bdx, bdy, cdx, cdy))
+	det = det.add(lift(bdx, bdy).mul(cross(cdx, cdy, adx, ady)))
+	det = det.add(lift(cdx, cdy).mul(cross(adx, ady, bdx, bdy)))
+	return det.sign()
+}
+
+// PolyOrient2D generalizes Orient2D to a polygon with n vertices, whose
+// coordinates are returned by calling the given function with each index
+// from zero to n-1. The result is positive if the vertices are in
+// anti-clockwise order, negative if they are in clockwise order, and zero
+// if the polygon encloses no area, assuming a Y axis that increases upward.
+//
+// The result approximates twice the signed area of the polygon, counting
+// areas that the polygon winds around more than once accordingly, but its
+// sign is always exactly correct.
+func PolyOrient2D(n int, vertex func(i int) (x, y float64)) float64 {
+	if n < 3 {
+		return 0
+	}
+
+	// The area is the sum of the cross products of the consecutive
+	// vertices, each of which has a rounding error of at most epsilon
+	// times its magnitude. Each of the additions can then add an error of
+	// at most epsilon times the sum of the magnitudes of the terms so far.
+	var det, permanent float64
+	px, py := vertex(n - 1)
+	for i := 0; i < n; i++ {
+		x, y := vertex(i)
+		l, r := px*y, py*x
+		det += l - r
+		permanent += math.Abs(l) + math.Abs(r)
+		px, py = x, y
+	}
+	if errBound := float64(2*n+2) * epsilon * permanent * (1 + epsilon); det > errBound || -det > errBound {
+		return det
+	}
+
+	exact := expansion{0}
+	px, py = vertex(n - 1)
+	for i := 0; i < n; i++ {
+		x, y := vertex(i)
+		t := twoProductDiff(px, y, py, x)
+		exact = exact.add(t[:])
+		px, py = x, y
+	}
+	return exact.sign()
+}
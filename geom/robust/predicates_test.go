@@ -0,0 +1,214 @@
+package robust
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestOrient2D(t *testing.T) {
+	tests := []struct {
+		A, B, C [2]float64
+		Want    int
+	}{
+		{[2]float64{0, 0}, [2]float64{1, 0}, [2]float64{0, 1}, 1},
+		{[2]float64{0, 0}, [2]float64{0, 1}, [2]float64{1, 0}, -1},
+		{[2]float64{0, 0}, [2]float64{1, 1}, [2]float64{2, 2}, 0},
+		{
+			// The naive calculation finds these to be collinear.
+			[2]float64{0.5, 0.5},
+			[2]float64{12, 12},
+			[2]float64{24, 24.000000000000004},
+			1,
+		},
+		{
+			[2]float64{0.5000000000000001, 0.5},
+			[2]float64{12, 12},
+			[2]float64{24, 24},
+			-1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %v %v", test.A, test.B, test.C), func(t *testing.T) {
+			got := Orient2D(test.A[0], test.A[1], test.B[0], test.B[1], test.C[0], test.C[1])
+			if sign(got) != test.Want {
+				t.Errorf("wrong result %g; want sign %d", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestOrient2DNearlyCollinear(t *testing.T) {
+	// Points on a fine grid near the line through two distant points are
+	// the classic case where the naive calculation gives inconsistent
+	// results.
+	bx, by, cx, cy := 12.0, 12.0, 24.0, 24.0
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 64; j++ {
+			ax := 0.5 + float64(i)*math.Pow(2, -53)
+			ay := 0.5 + float64(j)*math.Pow(2, -53)
+			got := sign(Orient2D(ax, ay, bx, by, cx, cy))
+			want := exactOrient2D(ax, ay, bx, by, cx, cy)
+			if got != want {
+				t.Fatalf("wrong sign %d for (%v, %v); want %d", got, ax, ay, want)
+			}
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	// The unit circle through three of its points, with the fourth point
+	// nudged around the circle's edge.
+	ax, ay, bx, by, cx, cy := 1.0, 0.0, 0.0, 1.0, -1.0, 0.0
+	tests := []struct {
+		D    [2]float64
+		Want int
+	}{
+		{[2]float64{0, 0}, 1},
+		{[2]float64{2, 2}, -1},
+		{[2]float64{0, -1}, 0},
+		{[2]float64{0, math.Nextafter(-1, 0)}, 1},
+		{[2]float64{0, math.Nextafter(-1, -2)}, -1},
+		{[2]float64{math.Sqrt(0.5), math.Sqrt(0.5)}, exactInCircle(ax, ay, bx, by, cx, cy, math.Sqrt(0.5), math.Sqrt(0.5))},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test.D), func(t *testing.T) {
+			got := InCircle(ax, ay, bx, by, cx, cy, test.D[0], test.D[1])
+			if sign(got) != test.Want {
+				t.Errorf("wrong result %g; want sign %d", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestPredicatesRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	// Nearly degenerate inputs come from perturbing points that are
+	// exactly collinear or cocircular by a few units in the last place.
+	nudge := func(v float64) float64 {
+		for n := rng.Intn(5) - 2; n != 0; {
+			if n > 0 {
+				v = math.Nextafter(v, math.Inf(1))
+				n--
+			} else {
+				v = math.Nextafter(v, math.Inf(-1))
+				n++
+			}
+		}
+		return v
+	}
+
+	for i := 0; i < 2000; i++ {
+		s := rng.Float64()
+		ax, ay := rng.Float64()*100, rng.Float64()*100
+		dx, dy := rng.Float64()*100, rng.Float64()*100
+		bx, by := nudge(ax+dx*s), nudge(ay+dy*s)
+		cx, cy := nudge(ax+dx), nudge(ay+dy)
+		got := sign(Orient2D(ax, ay, bx, by, cx, cy))
+		if want := exactOrient2D(ax, ay, bx, by, cx, cy); got != want {
+			t.Fatalf("Orient2D has wrong sign %d for %v; want %d", got, []float64{ax, ay, bx, by, cx, cy}, want)
+		}
+
+		var pts [8]float64
+		for j := 0; j < 4; j++ {
+			theta := rng.Float64() * 2 * math.Pi
+			pts[j*2] = nudge(50 + 30*math.Cos(theta))
+			pts[j*2+1] = nudge(50 + 30*math.Sin(theta))
+		}
+		got = sign(InCircle(pts[0], pts[1], pts[2], pts[3], pts[4], pts[5], pts[6], pts[7]))
+		if want := exactInCircle(pts[0], pts[1], pts[2], pts[3], pts[4], pts[5], pts[6], pts[7]); got != want {
+			t.Fatalf("InCircle has wrong sign %d for %v; want %d", got, pts, want)
+		}
+
+		poly := make([]float64, 2*(3+rng.Intn(6)))
+		for j := range poly {
+			poly[j] = nudge(float64(1 + rng.Intn(4)))
+		}
+		n := len(poly) / 2
+		vertex := func(i int) (float64, float64) { return poly[i*2], poly[i*2+1] }
+		got = sign(PolyOrient2D(n, vertex))
+		if want := exactPolyOrient2D(n, vertex); got != want {
+			t.Fatalf("PolyOrient2D has wrong sign %d for %v; want %d", got, poly, want)
+		}
+	}
+}
+
+func TestPolyOrient2D(t *testing.T) {
+	tests := []struct {
+		Poly []float64
+		Want float64
+	}{
+		{[]float64{0, 0, 2, 0, 2, 2, 0, 2}, 8},
+		{[]float64{0, 0, 0, 2, 2, 2, 2, 0}, -8},
+		{[]float64{0, 0, 1, 1, 2, 2}, 0},
+		{[]float64{0, 0, 1, 1}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test.Poly), func(t *testing.T) {
+			got := PolyOrient2D(len(test.Poly)/2, func(i int) (float64, float64) {
+				return test.Poly[i*2], test.Poly[i*2+1]
+			})
+			if got != test.Want {
+				t.Errorf("wrong result %g; want %g", got, test.Want)
+			}
+		})
+	}
+}
+
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+func rat(v float64) *big.Rat {
+	return new(big.Rat).SetFloat64(v)
+}
+
+func exactOrient2D(ax, ay, bx, by, cx, cy float64) int {
+	acx := new(big.Rat).Sub(rat(ax), rat(cx))
+	bcx := new(big.Rat).Sub(rat(bx), rat(cx))
+	acy := new(big.Rat).Sub(rat(ay), rat(cy))
+	bcy := new(big.Rat).Sub(rat(by), rat(cy))
+	l := new(big.Rat).Mul(acx, bcy)
+	r := new(big.Rat).Mul(acy, bcx)
+	return l.Cmp(r)
+}
+
+func exactInCircle(ax, ay, bx, by, cx, cy, dx, dy float64) int {
+	sub := func(a, b float64) *big.Rat { return new(big.Rat).Sub(rat(a), rat(b)) }
+	mul := func(a, b *big.Rat) *big.Rat { return new(big.Rat).Mul(a, b) }
+	add := func(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+	adx, ady := sub(ax, dx), sub(ay, dy)
+	bdx, bdy := sub(bx, dx), sub(by, dy)
+	cdx, cdy := sub(cx, dx), sub(cy, dy)
+	cross := func(ux, uy, vx, vy *big.Rat) *big.Rat {
+		return new(big.Rat).Sub(mul(ux, vy), mul(uy, vx))
+	}
+	lift := func(x, y *big.Rat) *big.Rat { return add(mul(x, x), mul(y, y)) }
+	det := mul(lift(adx, ady), cross(bdx, bdy, cdx, cdy))
+	det = add(det, mul(lift(bdx, bdy), cross(cdx, cdy, adx, ady)))
+	det = add(det, mul(lift(cdx, cdy), cross(adx, ady, bdx, bdy)))
+	return det.Sign()
+}
+
+func exactPolyOrient2D(n int, vertex func(i int) (float64, float64)) int {
+	det := new(big.Rat)
+	px, py := vertex(n - 1)
+	for i := 0; i < n; i++ {
+		x, y := vertex(i)
+		det.Add(det, new(big.Rat).Mul(rat(px), rat(y)))
+		det.Sub(det, new(big.Rat).Mul(rat(py), rat(x)))
+		px, py = x, y
+	}
+	return det.Sign()
+}
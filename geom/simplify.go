@@ -0,0 +1,442 @@
+package geom
+
+import (
+	"container/heap"
+	"math"
+)
+
+// SimplifyDP returns a simplified version of the receiving sequence using
+// the Douglas-Peucker algorithm, which retains only enough of the points
+// for every removed point to be within the given distance of the result.
+//
+// The first and last points are always retained. The result does not share
+// a backing array with the receiver.
+func (s LineSegSeq) SimplifyDP(tolerance float64) LineSegSeq {
+	if len(s) < 3 {
+		ret := make(LineSegSeq, len(s))
+		copy(ret, s)
+		return ret
+	}
+	keep := make([]bool, len(s))
+	douglasPeucker(s, 0, len(s)-1, tolerance, keep)
+	return LineSegSeq(kept(s, keep))
+}
+
+// SimplifyVW returns a simplified version of the receiving sequence using
+// the Visvalingam-Whyatt algorithm, which repeatedly removes the point that
+// forms the smallest triangle with its neighbors until all of the remaining
+// triangles have an area of at least the given tolerance.
+//
+// Note that the tolerance for this method is an area, rather than a
+// distance as for SimplifyDP.
+//
+// The first and last points are always retained. The result does not share
+// a backing array with the receiver.
+func (s LineSegSeq) SimplifyVW(tolerance float64) LineSegSeq {
+	vw := newVisvalingam(s, false)
+	vw.run(tolerance, 2, nil)
+	return LineSegSeq(vw.result())
+}
+
+// SimplifyDP returns a simplified version of the receiving polygon using
+// the Douglas-Peucker algorithm, which retains only enough of the vertices
+// for every removed vertex to be within the given distance of the result.
+//
+// The result always has at least three vertices if the receiver does, but
+// may be self-intersecting even if the receiver is not. Use
+// SimplifyPreservingTopology to avoid that.
+func (p Poly) SimplifyDP(tolerance float64) Poly {
+	if len(p) < 4 {
+		return p.Poly()
+	}
+
+	// We split the ring into two chains at the first vertex and the vertex
+	// furthest from it, which are both certain to be retained.
+	far, farDist := 0, 0.0
+	for i, v := range p {
+		if d := v.Sub(p[0]).LenSq(); d > farDist {
+			far, farDist = i, d
+		}
+	}
+	if far == 0 {
+		// All of the vertices are in the same place.
+		return Poly{p[0], p[1], p[2]}
+	}
+
+	ring := make([]Point, len(p)+1)
+	copy(ring, p)
+	ring[len(p)] = p[0]
+	keep := make([]bool, len(ring))
+	douglasPeucker(ring, 0, far, tolerance, keep)
+	douglasPeucker(ring, far, len(p), tolerance, keep)
+	keep[len(p)] = false
+
+	count := 0
+	for _, k := range keep {
+		if k {
+			count++
+		}
+	}
+	if count < 3 {
+		// The whole polygon is within the tolerance of the line between
+		// the two vertices we started with, so we'll keep whichever other
+		// vertex is furthest from that line.
+		best, bestDist := -1, -1.0
+		for i, v := range p {
+			if keep[i] {
+				continue
+			}
+			if d := math.Abs(orient(p[0], p[far], v)); d > bestDist {
+				best, bestDist = i, d
+			}
+		}
+		keep[best] = true
+	}
+	return Poly(kept(p, keep))
+}
+
+// SimplifyVW returns a simplified version of the receiving polygon using
+// the Visvalingam-Whyatt algorithm, which repeatedly removes the vertex that
+// forms the smallest triangle with its neighbors until all of the remaining
+// triangles have an area of at least the given tolerance.
+//
+// Note that the tolerance for this method is an area, rather than a
+// distance as for SimplifyDP.
+//
+// The result always has at least three vertices if the receiver does, but
+// may be self-intersecting even if the receiver is not. Use
+// SimplifyPreservingTopology to avoid that.
+func (p Poly) SimplifyVW(tolerance float64) Poly {
+	vw := newVisvalingam(p, true)
+	vw.run(tolerance, 3, nil)
+	return Poly(vw.result())
+}
+
+// SimplifyPreservingTopology is like SimplifyVW, except that it refuses to
+// remove any vertex whose removal would cause the polygon's edges to
+// intersect one another.
+//
+// If the receiver is not self-intersecting then the result is guaranteed
+// not to be self-intersecting either, though it may therefore retain some
+// vertices that SimplifyVW would have removed.
+func (p Poly) SimplifyPreservingTopology(tolerance float64) Poly {
+	vw := newVisvalingam(p, true)
+	grid := newSegGrid(vw.pts, vw.next)
+	vw.run(tolerance, 3, grid)
+	return Poly(vw.result())
+}
+
+// douglasPeucker marks in keep the points between indices first and last
+// inclusive that must be retained to keep all of the others within the
+// given tolerance.
+func douglasPeucker(pts []Point, first, last int, tolerance float64, keep []bool) {
+	keep[first] = true
+	keep[last] = true
+
+	stack := [][2]int{{first, last}}
+	for len(stack) > 0 {
+		r := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		seg := LineSeg{pts[r[0]], pts[r[1]]}
+		far, farDist := -1, tolerance
+		for i := r[0] + 1; i < r[1]; i++ {
+			if d := segDist(seg, pts[i]); d > farDist {
+				far, farDist = i, d
+			}
+		}
+		if far < 0 {
+			continue
+		}
+		keep[far] = true
+		stack = append(stack, [2]int{r[0], far}, [2]int{far, r[1]})
+	}
+}
+
+func kept(pts []Point, keep []bool) []Point {
+	ret := make([]Point, 0, len(pts))
+	for i, p := range pts {
+		if keep[i] {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// segDist returns the distance from the point p to the nearest point on the
+// line segment s.
+func segDist(s LineSeg, p Point) float64 {
+	d := s[1].Sub(s[0])
+	l := d.LenSq()
+	q := s[0]
+	if l > 0 {
+		t := p.Sub(s[0]).Dot(d) / l
+		switch {
+		case t >= 1:
+			q = s[1]
+		case t > 0:
+			q = s[0].Add(d.Scale(t))
+		}
+	}
+	return p.Dist(q)
+}
+
+// visvalingam is the state of a Visvalingam-Whyatt simplification, where the
+// remaining points are represented as a doubly-linked list.
+type visvalingam struct {
+	pts        []Point
+	prev, next []int
+	area       []float64
+	removed    []bool
+	remain     int
+	queue      vwQueue
+}
+
+func newVisvalingam(pts []Point, closed bool) *visvalingam {
+	n := len(pts)
+	vw := &visvalingam{
+		pts:     pts,
+		prev:    make([]int, n),
+		next:    make([]int, n),
+		area:    make([]float64, n),
+		removed: make([]bool, n),
+		remain:  n,
+	}
+	for i := range pts {
+		vw.prev[i] = i - 1
+		vw.next[i] = i + 1
+	}
+	if n == 0 {
+		return vw
+	}
+	if closed {
+		vw.prev[0] = n - 1
+		vw.next[n-1] = 0
+	} else {
+		vw.next[n-1] = -1
+	}
+	for i := range pts {
+		if vw.prev[i] < 0 || vw.next[i] < 0 {
+			// The ends of an open sequence are never removed.
+			vw.area[i] = math.Inf(1)
+			continue
+		}
+		vw.area[i] = vw.triArea(i)
+		vw.queue = append(vw.queue, vwItem{i, vw.area[i]})
+	}
+	heap.Init(&vw.queue)
+	return vw
+}
+
+func (vw *visvalingam) triArea(i int) float64 {
+	return math.Abs(orient(vw.pts[vw.prev[i]], vw.pts[i], vw.pts[vw.next[i]])) / 2
+}
+
+// run removes points until all remaining points have an effective area of
+// at least the given tolerance or there are only min points left.
+//
+// If grid is non-nil then removals that would cause edges to intersect are
+// skipped.
+func (vw *visvalingam) run(tolerance float64, min int, grid *segGrid) {
+	var blocked []vwItem
+	maxArea := 0.0
+	for vw.queue.Len() > 0 && vw.remain > min {
+		item := heap.Pop(&vw.queue).(vwItem)
+		i := item.index
+		if vw.removed[i] || item.area != vw.area[i] {
+			continue // stale entry
+		}
+		if item.area >= tolerance {
+			break
+		}
+		p, n := vw.prev[i], vw.next[i]
+		if grid != nil && grid.crosses(p, n, i) {
+			blocked = append(blocked, item)
+			continue
+		}
+
+		if grid != nil {
+			grid.remove(p, i)
+			grid.remove(i, n)
+			grid.insert(p, n)
+		}
+		vw.removed[i] = true
+		vw.remain--
+		vw.next[p] = n
+		vw.prev[n] = p
+
+		// Areas are never allowed to decrease below that of the point we
+		// just removed, so that removals happen in a consistent order.
+		if item.area > maxArea {
+			maxArea = item.area
+		}
+		for _, j := range [2]int{p, n} {
+			if math.IsInf(vw.area[j], 1) {
+				continue
+			}
+			vw.area[j] = math.Max(vw.triArea(j), maxArea)
+			heap.Push(&vw.queue, vwItem{j, vw.area[j]})
+		}
+
+		// Removing a point may unblock points that were previously blocked.
+		for _, b := range blocked {
+			if !vw.removed[b.index] && b.area == vw.area[b.index] {
+				heap.Push(&vw.queue, b)
+			}
+		}
+		blocked = blocked[:0]
+	}
+}
+
+func (vw *visvalingam) result() []Point {
+	ret := make([]Point, 0, vw.remain)
+	for i, p := range vw.pts {
+		if !vw.removed[i] {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+type vwItem struct {
+	index int
+	area  float64
+}
+
+// vwQueue is a min-heap of points ordered by their effective area.
+type vwQueue []vwItem
+
+func (q vwQueue) Len() int            { return len(q) }
+func (q vwQueue) Less(i, j int) bool  { return q[i].area < q[j].area }
+func (q vwQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *vwQueue) Push(x interface{}) { *q = append(*q, x.(vwItem)) }
+func (q *vwQueue) Pop() interface{} {
+	old := *q
+	ret := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ret
+}
+
+// segGrid is a uniform grid of cells that each record the edges of a ring
+// passing through them, for quickly finding edges that might intersect a
+// given segment.
+//
+// Each edge is identified by the indices of its start and end points.
+type segGrid struct {
+	pts   []Point
+	min   Point
+	size  float64
+	cols  int
+	rows  int
+	cells [][][2]int
+}
+
+func newSegGrid(pts []Point, next []int) *segGrid {
+	g := &segGrid{pts: pts}
+	if len(pts) == 0 {
+		return g
+	}
+	var total float64
+	for i, p := range pts {
+		total += p.Dist(pts[next[i]])
+	}
+	bounds := BoundingRect(pts)
+	min, max := bounds.Min(), bounds.Max()
+
+	// Cells roughly the size of an average edge keep the number of edges
+	// per cell small, but we limit the total number of cells too.
+	g.size = total / float64(len(pts))
+	ext := math.Max(max.X-min.X, max.Y-min.Y)
+	if limit := ext / math.Sqrt(float64(4*len(pts))); g.size < limit {
+		g.size = limit
+	}
+	if g.size == 0 {
+		g.size = 1
+	}
+	g.min = min
+	g.cols = int((max.X-min.X)/g.size) + 1
+	g.rows = int((max.Y-min.Y)/g.size) + 1
+	g.cells = make([][][2]int, g.cols*g.rows)
+	for i := range pts {
+		g.insert(i, next[i])
+	}
+	return g
+}
+
+func (g *segGrid) cellRange(a, b int) (int, int, int, int) {
+	pa, pb := g.pts[a], g.pts[b]
+	x0 := int((math.Min(pa.X, pb.X) - g.min.X) / g.size)
+	x1 := int((math.Max(pa.X, pb.X) - g.min.X) / g.size)
+	y0 := int((math.Min(pa.Y, pb.Y) - g.min.Y) / g.size)
+	y1 := int((math.Max(pa.Y, pb.Y) - g.min.Y) / g.size)
+	return x0, y0, x1, y1
+}
+
+func (g *segGrid) insert(a, b int) {
+	x0, y0, x1, y1 := g.cellRange(a, b)
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			c := y*g.cols + x
+			g.cells[c] = append(g.cells[c], [2]int{a, b})
+		}
+	}
+}
+
+func (g *segGrid) remove(a, b int) {
+	x0, y0, x1, y1 := g.cellRange(a, b)
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			c := y*g.cols + x
+			cell := g.cells[c]
+			for i, e := range cell {
+				if e[0] == a && e[1] == b {
+					cell[i] = cell[len(cell)-1]
+					g.cells[c] = cell[:len(cell)-1]
+					break
+				}
+			}
+		}
+	}
+}
+
+// crosses returns true if the segment from point a to point b would touch
+// any edge in the grid other than those connected to a, b or skip.
+func (g *segGrid) crosses(a, b, skip int) bool {
+	seg := LineSeg{g.pts[a], g.pts[b]}
+	x0, y0, x1, y1 := g.cellRange(a, b)
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			for _, e := range g.cells[y*g.cols+x] {
+				switch {
+				case e[0] == a || e[1] == a || e[0] == b || e[1] == b:
+					continue
+				case e[0] == skip || e[1] == skip:
+					continue
+				}
+				if segsTouch(seg, LineSeg{g.pts[e[0]], g.pts[e[1]]}) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// segsTouch returns true if the two given line segments have at least one
+// point in common.
+func segsTouch(s, o LineSeg) bool {
+	d1 := orient(o[0], o[1], s[0])
+	d2 := orient(o[0], o[1], s[1])
+	d3 := orient(s[0], s[1], o[0])
+	d4 := orient(s[0], s[1], o[1])
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	onSeg := func(s LineSeg, p Point) bool {
+		return Rect(s).Contains(p)
+	}
+	return (d1 == 0 && onSeg(o, s[0])) ||
+		(d2 == 0 && onSeg(o, s[1])) ||
+		(d3 == 0 && onSeg(s, o[0])) ||
+		(d4 == 0 && onSeg(s, o[1]))
+}
@@ -0,0 +1,131 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestLineSegSeqSimplify(t *testing.T) {
+	tests := []struct {
+		Seq       LineSegSeq
+		Tolerance float64
+		WantDP    LineSegSeq
+		WantVW    LineSegSeq
+	}{
+		{
+			LineSegSeq{{0, 0}, {1, 1}},
+			10,
+			LineSegSeq{{0, 0}, {1, 1}},
+			LineSegSeq{{0, 0}, {1, 1}},
+		},
+		{
+			LineSegSeq{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 5}, {4, 6}, {5, 7.1}, {6, 8}},
+			0.5,
+			LineSegSeq{{0, 0}, {2, -0.1}, {3, 5}, {6, 8}},
+			LineSegSeq{{0, 0}, {2, -0.1}, {3, 5}, {6, 8}},
+		},
+		{
+			LineSegSeq{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 5}, {4, 6}, {5, 7.1}, {6, 8}},
+			0.01,
+			LineSegSeq{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 5}, {4, 6}, {5, 7.1}, {6, 8}},
+			LineSegSeq{{0, 0}, {1, 0.1}, {2, -0.1}, {3, 5}, {4, 6}, {5, 7.1}, {6, 8}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %f", test.Seq, test.Tolerance), func(t *testing.T) {
+			for _, problem := range deep.Equal(test.Seq.SimplifyDP(test.Tolerance), test.WantDP) {
+				t.Errorf("DP: %s", problem)
+			}
+			for _, problem := range deep.Equal(test.Seq.SimplifyVW(test.Tolerance), test.WantVW) {
+				t.Errorf("VW: %s", problem)
+			}
+		})
+	}
+}
+
+func TestPolySimplify(t *testing.T) {
+	// A square with slightly wobbly edges.
+	square := Poly{
+		{0, 0}, {5, 0.1}, {10, 0},
+		{10.1, 5}, {10, 10},
+		{5, 9.9}, {0, 10},
+		{-0.1, 5},
+	}
+	want := Poly{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	for _, problem := range deep.Equal(square.SimplifyDP(0.5), want) {
+		t.Errorf("DP: %s", problem)
+	}
+	for _, problem := range deep.Equal(square.SimplifyVW(1), want) {
+		t.Errorf("VW: %s", problem)
+	}
+	for _, problem := range deep.Equal(square.SimplifyPreservingTopology(1), want) {
+		t.Errorf("PreservingTopology: %s", problem)
+	}
+
+	// Simplification never produces fewer than three vertices.
+	for _, problem := range deep.Equal(len(square.SimplifyDP(1000)), 3) {
+		t.Errorf("DP: %s", problem)
+	}
+	for _, problem := range deep.Equal(len(square.SimplifyVW(1000)), 3) {
+		t.Errorf("VW: %s", problem)
+	}
+}
+
+func TestPolySimplifyPreservingTopology(t *testing.T) {
+	// The vertex at (10, -1) has the smallest area, but removing it would
+	// cause the new edge to cross the spike that dips down to (10, -0.5).
+	// The vertex at (5, 10) is redundant and can be removed either way.
+	c := Poly{
+		{0, 0}, {10, -1}, {20, 0}, {20, 10}, {11, 10}, {10, -0.5}, {9, 10},
+		{5, 10}, {0, 10},
+	}
+
+	got := c.SimplifyPreservingTopology(10.2)
+	if !simple(got) {
+		t.Errorf("result is self-intersecting: %#v", got)
+	}
+	if simple(c.SimplifyVW(10.2)) {
+		t.Errorf("SimplifyVW result is not self-intersecting, so this test is ineffective")
+	}
+	if len(got) >= len(c) {
+		t.Errorf("no vertices removed: %#v", got)
+	}
+}
+
+// simple is a brute-force check for whether any two non-adjacent edges of
+// a polygon touch.
+func simple(p Poly) bool {
+	n := len(p)
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			a := LineSeg{p[i], p[(i+1)%n]}
+			b := LineSeg{p[j], p[(j+1)%n]}
+			if segsTouch(a, b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSegDist(t *testing.T) {
+	s := LineSeg{{0, 0}, {10, 0}}
+	for p, want := range map[Point]float64{
+		{5, 3}:   3,
+		{-3, 4}:  5,
+		{13, -4}: 5,
+		{10, 0}:  0,
+	} {
+		if got := segDist(s, p); math.Abs(got-want) > 1e-12 {
+			t.Errorf("wrong distance to %#v: got %f, want %f", p, got, want)
+		}
+	}
+}
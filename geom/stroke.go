@@ -0,0 +1,322 @@
+package geom
+
+import (
+	"math"
+)
+
+// LineCap selects the shape drawn at the ends of an open stroked path.
+type LineCap int
+
+const (
+	// ButtCap ends the stroke exactly at the end of the path.
+	ButtCap LineCap = iota
+
+	// RoundCap extends the stroke with a half-circle whose diameter is the
+	// stroke width.
+	RoundCap
+
+	// SquareCap extends the stroke by half of its width.
+	SquareCap
+)
+
+// LineJoin selects the shape drawn where two stroked segments meet.
+type LineJoin int
+
+const (
+	// MiterJoin extends the outer edges of the two segments until they
+	// meet, unless that would exceed the miter limit, in which case a
+	// bevel is used instead.
+	MiterJoin LineJoin = iota
+
+	// RoundJoin fills the gap between the segments with a circular arc.
+	RoundJoin
+
+	// BevelJoin fills the gap between the segments with a straight line.
+	BevelJoin
+)
+
+// DefaultMiterLimit is the miter limit used by a StrokeStyle whose
+// MiterLimit is zero, which is the same as the default in SVG.
+const DefaultMiterLimit = 4
+
+// StrokeStyle describes how to draw a path as a line of a particular width.
+// The zero value describes a stroke of zero width, which covers no area.
+type StrokeStyle struct {
+	Width float64
+	Cap   LineCap
+	Join  LineJoin
+
+	// MiterLimit is the largest ratio of miter length to stroke width for
+	// which MiterJoin joins are mitered. Zero selects DefaultMiterLimit.
+	MiterLimit float64
+
+	// Dashes, if not empty, gives the lengths of alternating dashes and
+	// gaps, starting with a dash. A list with an odd number of elements is
+	// repeated to make it even, as in SVG.
+	Dashes []float64
+
+	// DashOffset is the distance into the dash pattern at which the path
+	// begins.
+	DashOffset float64
+}
+
+// Polys returns polygons that together cover the area of the given sequence
+// of line segments when stroked with the receiving style. If closed is true
+// then the sequence is treated as returning to its start point, with a join
+// rather than caps there.
+//
+// The result contains a separate polygon for each segment, join and cap,
+// all of which are anti-clockwise and many of which overlap, so they should
+// be filled using a non-zero winding rule. Round joins and caps are
+// approximated to the given tolerance.
+func (st StrokeStyle) Polys(seq LineSegSeq, closed bool, tolerance float64) []Poly {
+	if st.Width <= 0 || len(seq) == 0 {
+		return nil
+	}
+	if st.dashed() {
+		var ret []Poly
+		for _, dash := range seq.Dash(st.Dashes, st.DashOffset, closed) {
+			ret = append(ret, st.polys(dash, false, tolerance)...)
+		}
+		return ret
+	}
+	return st.polys(seq, closed, tolerance)
+}
+
+func (st StrokeStyle) dashed() bool {
+	for _, l := range st.Dashes {
+		if l > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (st StrokeStyle) polys(seq LineSegSeq, closed bool, tolerance float64) []Poly {
+	pts := seq.Distinct(closed)
+	hw := st.Width / 2
+	var ret []Poly
+
+	if len(pts) == 1 {
+		// A path of zero length is drawn only by its caps, which are then
+		// aligned with the X axis.
+		switch st.Cap {
+		case RoundCap:
+			ret = append(ret, roundPoly(pts[0], Point{hw, 0}, 2*math.Pi, hw, tolerance))
+		case SquareCap:
+			d := Point{hw, hw}
+			ret = append(ret, Rect{pts[0].Sub(d), pts[0].Add(d)}.Poly())
+		}
+		return ret
+	}
+
+	n := len(pts) - 1
+	if closed {
+		n = len(pts)
+	}
+	normal := func(i int) Point {
+		return pts[(i+1)%len(pts)].Sub(pts[i]).Normalize().Perp().Scale(hw)
+	}
+	for i := 0; i < n; i++ {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		nv := normal(i)
+		ret = append(ret, Poly{a.Sub(nv), b.Sub(nv), b.Add(nv), a.Add(nv)})
+		if i == n-1 && !closed {
+			break
+		}
+		if join := st.JoinPoly(b, nv, normal((i+1)%len(pts)), tolerance); join != nil {
+			ret = append(ret, join)
+		}
+	}
+
+	if !closed {
+		n0 := normal(0)
+		n1 := normal(len(pts) - 2)
+		ret = append(ret, st.cap(pts[0], n0, true, tolerance)...)
+		ret = append(ret, st.cap(pts[len(pts)-1], n1, false, tolerance)...)
+	}
+
+	for i, p := range ret {
+		if p.dirArea() > 0 {
+			ret[i] = reversePoly(p)
+		}
+	}
+	return ret
+}
+
+// JoinPoly returns a polygon filling the gap on the outside of the turn at
+// point v between two stroked segments, or nil if there is no gap. n0 and n1
+// are the left-hand normals of the segments before and after v, each with a
+// length of half of the stroke width. Round joins are approximated to the
+// given tolerance.
+//
+// The polygon is convex and its first vertex is v, so it can be divided into
+// triangles that all share that vertex.
+func (st StrokeStyle) JoinPoly(v, n0, n1 Point, tolerance float64) Poly {
+	cross, dot := n0.Cross(n1), n0.Dot(n1)
+	if cross == 0 && dot > 0 {
+		return nil
+	}
+	hw := n0.Len()
+
+	// The outside of the turn is on the right for anti-clockwise turns,
+	// and we treat a complete reversal as an anti-clockwise turn.
+	side := -1.0
+	if cross < 0 {
+		side = 1
+	}
+	p0, p1 := v.Add(n0.Scale(side)), v.Add(n1.Scale(side))
+
+	switch st.Join {
+	case RoundJoin:
+		return roundPoly(v, n0.Scale(side), n0.AngleBetween(n1), hw, tolerance)
+	case MiterJoin:
+		limit := st.MiterLimit
+		if limit == 0 {
+			limit = DefaultMiterLimit
+		}
+		cosHalf := math.Sqrt((1 + dot/(hw*hw)) / 2)
+		if cosHalf > 0 && 1/cosHalf <= limit {
+			tip := v.Add(n0.Add(n1).Normalize().Scale(side * hw / cosHalf))
+			return Poly{v, p0, tip, p1}
+		}
+	}
+	return Poly{v, p0, p1}
+}
+
+// cap returns the polygons for the cap at point p of a path whose segment
+// there has the given normal. start is true for the cap at the start of the
+// path.
+func (st StrokeStyle) cap(p, nv Point, start bool, tolerance float64) []Poly {
+	if !start {
+		nv = nv.Scale(-1)
+	}
+	switch st.Cap {
+	case RoundCap:
+		return []Poly{roundPoly(p, nv, math.Pi, nv.Len(), tolerance)}
+	case SquareCap:
+		// Rotating the normal a quarter turn points away from the path.
+		out := nv.Perp()
+		return []Poly{{p.Add(nv), p.Add(nv).Add(out), p.Sub(nv).Add(out), p.Sub(nv)}}
+	}
+	return nil
+}
+
+// roundPoly returns a polygon for a circular sector centered at c, starting
+// at c+from and turning through the given angle. A sector of a full turn
+// is returned as a circle. The arc is approximated by straight lines no
+// further than the given tolerance from the circle of radius r.
+func roundPoly(c, from Point, angle, r, tolerance float64) Poly {
+	n := ArcSteps(angle, r, tolerance)
+	full := math.Abs(angle) >= 2*math.Pi
+	ret := make(Poly, 0, n+2)
+	if !full {
+		ret = append(ret, c)
+	}
+	for i := 0; i <= n; i++ {
+		if full && i == n {
+			break
+		}
+		ret = append(ret, c.Add(from.Rotate(angle*float64(i)/float64(n))))
+	}
+	return ret
+}
+
+// ArcSteps returns the number of straight lines needed to approximate a
+// circular arc of the given angle and radius so that the lines are nowhere
+// further than the given tolerance from the arc.
+//
+// The result is at least one, and is limited to the same number of segments
+// as CubicCurve.Flatten produces, which is also the result if the tolerance
+// isn't greater than zero.
+func ArcSteps(angle, r, tolerance float64) int {
+	if tolerance <= 0 {
+		return maxFlattenSegments
+	}
+	step := math.Pi / 2
+	if tolerance < r {
+		step = math.Min(step, 2*math.Acos(1-tolerance/r))
+	}
+	n := math.Ceil(math.Abs(angle) / step)
+	return int(math.Max(1, math.Min(n, maxFlattenSegments)))
+}
+
+func reversePoly(p Poly) Poly {
+	ret := make(Poly, len(p))
+	for i, pt := range p {
+		ret[len(p)-1-i] = pt
+	}
+	return ret
+}
+
+// Dash divides the sequence into the parts that are covered by the dashes
+// of the given pattern, as described for StrokeStyle. If closed is true
+// then the sequence is treated as returning to its start point.
+//
+// The result is nil if the pattern has no positive lengths. A dash of zero
+// length produces a sequence whose points are all equal.
+func (s LineSegSeq) Dash(pattern []float64, offset float64, closed bool) []LineSegSeq {
+	if len(pattern)%2 == 1 {
+		pattern = append(pattern[:len(pattern):len(pattern)], pattern...)
+	}
+	var total float64
+	for _, l := range pattern {
+		total += math.Max(l, 0)
+	}
+	if total == 0 || len(s) == 0 {
+		return nil
+	}
+
+	pts := []Point(s)
+	if closed && pts[len(pts)-1] != pts[0] {
+		pts = append(pts[:len(pts):len(pts)], pts[0])
+	}
+
+	// Find where in the pattern the sequence begins. A part that ends
+	// exactly at the start is skipped, but a zero-length part there isn't,
+	// so that a pattern beginning with a zero-length dash puts a dot at the
+	// start of the sequence.
+	i := 0
+	remain := math.Mod(offset, total)
+	if remain < 0 {
+		remain += total
+	}
+	for l := math.Max(pattern[i], 0); remain > l || (remain == l && l > 0); l = math.Max(pattern[i], 0) {
+		remain -= l
+		i = (i + 1) % len(pattern)
+	}
+	remain = math.Max(pattern[i], 0) - remain
+
+	var ret []LineSegSeq
+	var cur LineSegSeq
+	on := i%2 == 0
+	if on {
+		cur = LineSegSeq{pts[0]}
+	}
+	for j := 1; j < len(pts); j++ {
+		a, b := pts[j-1], pts[j]
+		l := a.Dist(b)
+		pos := 0.0
+		for l-pos > remain {
+			pos += remain
+			pt := a.Lerp(b, pos/l)
+			if on {
+				ret = append(ret, cur.Append(pt))
+				cur = nil
+			} else {
+				cur = LineSegSeq{pt}
+			}
+			on = !on
+			i = (i + 1) % len(pattern)
+			remain = math.Max(pattern[i], 0)
+		}
+		remain -= l - pos
+		if on {
+			cur = cur.Append(b)
+		}
+	}
+	if on {
+		ret = append(ret, cur)
+	}
+	return ret
+}
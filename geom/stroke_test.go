@@ -0,0 +1,216 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestLineSegSeqDash(t *testing.T) {
+	tests := []struct {
+		Seq     LineSegSeq
+		Pattern []float64
+		Offset  float64
+		Closed  bool
+		Want    []LineSegSeq
+	}{
+		{
+			LineSegSeq{{0, 0}, {10, 0}},
+			nil,
+			0,
+			false,
+			nil,
+		},
+		{
+			LineSegSeq{{0, 0}, {10, 0}},
+			[]float64{3, 2},
+			0,
+			false,
+			[]LineSegSeq{
+				{{0, 0}, {3, 0}},
+				{{5, 0}, {8, 0}},
+			},
+		},
+		{
+			// Odd-length patterns repeat, so this is 3, 2, 3, 3, 2, 3
+			LineSegSeq{{0, 0}, {10, 0}},
+			[]float64{3, 2, 3},
+			1,
+			false,
+			[]LineSegSeq{
+				{{0, 0}, {2, 0}},
+				{{4, 0}, {7, 0}},
+			},
+		},
+		{
+			// Dashes continue around corners and along the closing line
+			LineSegSeq{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+			[]float64{6, 2},
+			0,
+			true,
+			[]LineSegSeq{
+				{{0, 0}, {4, 0}, {4, 2}},
+				{{4, 4}, {0, 4}, {0, 2}},
+			},
+		},
+		{
+			LineSegSeq{{0, 0}, {4, 0}},
+			[]float64{0, 2},
+			-1,
+			false,
+			[]LineSegSeq{
+				{{1, 0}, {1, 0}},
+				{{3, 0}, {3, 0}},
+			},
+		},
+		{
+			// A zero-length dash at the very start is still drawn
+			LineSegSeq{{0, 0}, {12, 0}},
+			[]float64{0, 5},
+			0,
+			false,
+			[]LineSegSeq{
+				{{0, 0}, {0, 0}},
+				{{5, 0}, {5, 0}},
+				{{10, 0}, {10, 0}},
+			},
+		},
+		{
+			// A dash that ends exactly at the start is skipped
+			LineSegSeq{{0, 0}, {10, 0}},
+			[]float64{3, 2},
+			3,
+			false,
+			[]LineSegSeq{
+				{{2, 0}, {5, 0}},
+				{{7, 0}, {10, 0}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v %#v %#v", test.Seq, test.Pattern, test.Offset), func(t *testing.T) {
+			got := test.Seq.Dash(test.Pattern, test.Offset, test.Closed)
+			if diff := deep.Equal(got, test.Want); diff != nil {
+				for _, problem := range diff {
+					t.Error(problem)
+				}
+			}
+		})
+	}
+}
+
+func TestArcSteps(t *testing.T) {
+	tests := []struct {
+		Angle, R, Tolerance float64
+		Want                int
+	}{
+		{math.Pi, 1, 2, 2},
+		{-math.Pi, 1, 2, 2},
+		{2 * math.Pi, 1, 1 - math.Cos(math.Pi/8), 8},
+		{0, 1, 0.1, 1},
+		{math.Pi, 1, 1e-30, maxFlattenSegments},
+		{math.Pi, 1, 0, maxFlattenSegments},
+		{math.Pi, 1, -1, maxFlattenSegments},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%g %g %g", test.Angle, test.R, test.Tolerance), func(t *testing.T) {
+			if got := ArcSteps(test.Angle, test.R, test.Tolerance); got != test.Want {
+				t.Errorf("wrong result %d; want %d", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestStrokeStylePolys(t *testing.T) {
+	// An L shape, whose total stroked area depends on the cap and join.
+	seq := LineSegSeq{{0, 0}, {10, 0}, {10, 10}}
+
+	tests := []struct {
+		Style StrokeStyle
+		Area  float64
+	}{
+		{
+			StrokeStyle{Width: 2, Cap: ButtCap, Join: BevelJoin},
+			40 + 0.5,
+		},
+		{
+			StrokeStyle{Width: 2, Cap: ButtCap, Join: MiterJoin},
+			40 + 1,
+		},
+		{
+			// A right angle needs a miter limit of at least sqrt(2)
+			StrokeStyle{Width: 2, Cap: ButtCap, Join: MiterJoin, MiterLimit: 1.4},
+			40 + 0.5,
+		},
+		{
+			StrokeStyle{Width: 2, Cap: SquareCap, Join: RoundJoin},
+			40 + math.Pi/4 + 4,
+		},
+		{
+			StrokeStyle{Width: 2, Cap: RoundCap, Join: RoundJoin},
+			40 + math.Pi/4 + math.Pi,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Style), func(t *testing.T) {
+			polys := test.Style.Polys(seq, false, 1e-4)
+			var area float64
+			for _, p := range polys {
+				if p.dirArea() > 0 {
+					t.Errorf("polygon is clockwise: %#v", p)
+				}
+				area += p.Area()
+			}
+			if math.Abs(area-test.Area) > 1e-3 {
+				t.Errorf("wrong total area %g; want %g", area, test.Area)
+			}
+		})
+	}
+}
+
+func TestStrokeStylePolysClosed(t *testing.T) {
+	// Each side of the square contributes a 4x2 rectangle and each corner a
+	// 1x1 miter, with no caps.
+	seq := LineSegSeq{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}
+	style := StrokeStyle{Width: 2, Cap: RoundCap}
+	polys := style.Polys(seq, true, 0.1)
+
+	var area float64
+	for _, p := range polys {
+		area += p.Area()
+	}
+	if want := 4*8 + 4*1.0; math.Abs(area-want) > 1e-9 {
+		t.Errorf("wrong total area %g; want %g", area, want)
+	}
+	if got, want := len(polys), 8; got != want {
+		t.Errorf("wrong number of polygons %d; want %d", got, want)
+	}
+}
+
+func TestStrokeStylePolysPoint(t *testing.T) {
+	seq := LineSegSeq{{1, 1}, {1, 1}}
+	tests := []struct {
+		Cap  LineCap
+		Area float64
+	}{
+		{ButtCap, 0},
+		{RoundCap, math.Pi},
+		{SquareCap, 4},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Cap), func(t *testing.T) {
+			var area float64
+			for _, p := range (StrokeStyle{Width: 2, Cap: test.Cap}).Polys(seq, false, 1e-4) {
+				area += p.Area()
+			}
+			if math.Abs(area-test.Area) > 1e-3 {
+				t.Errorf("wrong area %g; want %g", area, test.Area)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package geom
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// sweepIntersections finds all pairs of the given line segments that have
+// at least one point in common, using the Bentley-Ottmann sweep line
+// algorithm, and calls report once for each such pair with one of the points
+// the two segments share.
+//
+// Segments with zero length are ignored. The ordering of the calls to report
+// is deterministic but otherwise unspecified.
+func sweepIntersections(segs []LineSeg, report func(i, j int, p Point)) {
+	sw := &sweeper{
+		segs:     make([]sweepSeg, 0, len(segs)),
+		starts:   make(map[Point][]int),
+		queued:   make(map[Point]bool),
+		reported: make(map[[2]int]bool),
+		report:   report,
+	}
+
+	var scale float64
+	for i, s := range segs {
+		if s[0] == s[1] {
+			continue
+		}
+		a, b := s[0], s[1]
+		if sweepBefore(b, a) {
+			a, b = b, a
+		}
+		sw.segs = append(sw.segs, sweepSeg{a, b, i})
+		sw.starts[a] = append(sw.starts[a], len(sw.segs)-1)
+		sw.queue(a)
+		sw.queue(b)
+		scale = math.Max(scale, math.Max(math.Max(math.Abs(a.X), math.Abs(a.Y)), math.Max(math.Abs(b.X), math.Abs(b.Y))))
+	}
+
+	// Intersection points calculated during the sweep are subject to
+	// rounding errors, so we allow a small amount of slop when deciding
+	// whether such a point lies on a segment.
+	sw.eps = scale * 1e-10
+
+	for sw.events.Len() > 0 {
+		p := heap.Pop(&sw.events).(Point)
+		sw.handle(p)
+	}
+}
+
+type sweepSeg struct {
+	// a and b are the endpoints of the segment, with a before b in
+	// sweep order.
+	a, b Point
+
+	// id is the index of the segment in the caller's slice.
+	id int
+}
+
+// sweeper is the state of an in-progress Bentley-Ottmann sweep.
+//
+// The sweep line runs from left to right, with points that have the same X
+// coordinate visited in order of increasing Y coordinate.
+type sweeper struct {
+	segs []sweepSeg
+
+	// status is the segments crossing the sweep line, ordered from bottom to
+	// top at the current event point.
+	status []int
+
+	events   sweepQueue
+	starts   map[Point][]int
+	queued   map[Point]bool
+	reported map[[2]int]bool
+	report   func(i, j int, p Point)
+	eps      float64
+}
+
+func (sw *sweeper) queue(p Point) {
+	if sw.queued[p] {
+		return
+	}
+	sw.queued[p] = true
+	heap.Push(&sw.events, p)
+}
+
+func (sw *sweeper) handle(p Point) {
+	// Find the run of segments in the status that pass through p.
+	lo := sort.Search(len(sw.status), func(i int) bool {
+		return sw.yAt(sw.status[i], p) >= p.Y-sw.eps
+	})
+	hi := lo
+	for hi < len(sw.status) && sw.contains(sw.status[hi], p) {
+		hi++
+	}
+
+	starts := sw.starts[p]
+	if n := (hi - lo) + len(starts); n > 1 {
+		all := make([]int, 0, n)
+		all = append(all, sw.status[lo:hi]...)
+		all = append(all, starts...)
+		for i := range all {
+			for j := i + 1; j < len(all); j++ {
+				sw.found(all[i], all[j], p)
+			}
+		}
+	}
+
+	// The segments that continue beyond p are reinserted along with those
+	// starting at p, in their order just after p.
+	var next []int
+	for _, s := range sw.status[lo:hi] {
+		if sw.segs[s].b != p {
+			next = append(next, s)
+		}
+	}
+	next = append(next, starts...)
+	sort.SliceStable(next, func(i, j int) bool {
+		return sw.slope(next[i]) < sw.slope(next[j])
+	})
+
+	status := make([]int, 0, len(sw.status)-(hi-lo)+len(next))
+	status = append(status, sw.status[:lo]...)
+	status = append(status, next...)
+	status = append(status, sw.status[hi:]...)
+	sw.status = status
+
+	if len(next) == 0 {
+		if lo > 0 && lo < len(sw.status) {
+			sw.check(sw.status[lo-1], sw.status[lo], p)
+		}
+		return
+	}
+	if lo > 0 {
+		sw.check(sw.status[lo-1], sw.status[lo], p)
+	}
+	if last := lo + len(next); last < len(sw.status) {
+		sw.check(sw.status[last-1], sw.status[last], p)
+	}
+}
+
+// check queues an event for the intersection of the two given segments, if
+// they cross after the given point.
+//
+// A crossing that is calculated to be at or before the given point can only
+// be there due to rounding, since the segments would otherwise have been
+// reordered by an earlier event. Such a crossing is reported immediately as
+// if it were at the current event, rather than being lost.
+func (sw *sweeper) check(s1, s2 int, p Point) {
+	a, b := sw.segs[s1], sw.segs[s2]
+	x, ok := crossing(LineSeg{a.a, a.b}, LineSeg{b.a, b.b})
+	switch {
+	case !ok:
+	case sweepBefore(p, x):
+		sw.queue(x)
+	default:
+		sw.found(s1, s2, x)
+	}
+}
+
+func (sw *sweeper) found(s1, s2 int, p Point) {
+	i, j := sw.segs[s1].id, sw.segs[s2].id
+	if i > j {
+		i, j = j, i
+	}
+	k := [2]int{i, j}
+	if sw.reported[k] {
+		return
+	}
+	sw.reported[k] = true
+	sw.report(i, j, p)
+}
+
+// yAt returns the Y coordinate of the segment where it crosses the vertical
+// line through p.
+//
+// Event points calculated from crossings may be slightly off of the
+// segments they belong to, so the segment is considered to cover all of the
+// Y coordinates it reaches within the sweeper's tolerance of that line, and
+// the result is the one of those closest to p. In particular, a vertical
+// segment is treated as crossing at the point closest to p.
+func (sw *sweeper) yAt(s int, p Point) float64 {
+	seg := sw.segs[s]
+	a, b := seg.a, seg.b
+	if a.X == b.X {
+		return math.Min(math.Max(p.Y, math.Min(a.Y, b.Y)), math.Max(a.Y, b.Y))
+	}
+	y0 := seg.yAtX(math.Max(p.X-sw.eps, a.X))
+	y1 := seg.yAtX(math.Min(p.X+sw.eps, b.X))
+	return math.Min(math.Max(p.Y, math.Min(y0, y1)), math.Max(y0, y1))
+}
+
+// yAtX returns the Y coordinate of a non-vertical segment at the given X
+// coordinate.
+func (s sweepSeg) yAtX(x float64) float64 {
+	switch x {
+	case s.a.X:
+		return s.a.Y
+	case s.b.X:
+		return s.b.Y
+	}
+	return s.a.Y + (x-s.a.X)*(s.b.Y-s.a.Y)/(s.b.X-s.a.X)
+}
+
+// slope returns the slope of the given segment, which determines the order
+// of segments that meet at an event point just after that point. Vertical
+// segments have infinite slope and so are ordered above all others, since
+// they extend upwards from the point.
+func (sw *sweeper) slope(s int) float64 {
+	seg := sw.segs[s]
+	if seg.a.X == seg.b.X {
+		return math.Inf(1)
+	}
+	return (seg.b.Y - seg.a.Y) / (seg.b.X - seg.a.X)
+}
+
+// contains returns true if p lies on the given segment, within the
+// sweeper's tolerance.
+func (sw *sweeper) contains(s int, p Point) bool {
+	seg := sw.segs[s]
+	a, b := seg.a, seg.b
+	if p == a || p == b {
+		return true
+	}
+	if !(Rect{a, b}).Outset(sw.eps).Contains(p) {
+		return false
+	}
+	d := b.Sub(a)
+	return math.Abs(orient(a, b, p)) <= sw.eps*d.Len()
+}
+
+// sweepBefore returns true if p is visited before q during a sweep.
+func sweepBefore(p, q Point) bool {
+	return p.X < q.X || (p.X == q.X && p.Y < q.Y)
+}
+
+// crossing returns the single point where two line segments cross, if any.
+// The second result is false if the segments don't intersect or if they are
+// parallel.
+//
+// The result doesn't depend on the order of the segments or of their
+// endpoints, so the same crossing is always found at exactly the same point.
+func crossing(s, o LineSeg) (Point, bool) {
+	if sweepBefore(s[1], s[0]) {
+		s[0], s[1] = s[1], s[0]
+	}
+	if sweepBefore(o[1], o[0]) {
+		o[0], o[1] = o[1], o[0]
+	}
+	if sweepBefore(o[0], s[0]) || (o[0] == s[0] && sweepBefore(o[1], s[1])) {
+		s, o = o, s
+	}
+	d1, d2 := s[1].Sub(s[0]), o[1].Sub(o[0])
+	den := d1.X*d2.Y - d1.Y*d2.X
+	if den == 0 {
+		return Point{}, false
+	}
+	e := o[0].Sub(s[0])
+	t := (e.X*d2.Y - e.Y*d2.X) / den
+	u := (e.X*d1.Y - e.Y*d1.X) / den
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Point{}, false
+	}
+	switch {
+	case t == 0:
+		return s[0], true
+	case t == 1:
+		return s[1], true
+	case u == 0:
+		return o[0], true
+	case u == 1:
+		return o[1], true
+	}
+	// Rounding could put the result slightly outside of one of the
+	// segments, and in particular off of a vertical or horizontal segment,
+	// so we clamp it to both of their bounding rectangles.
+	x := s[0].Add(d1.Scale(t))
+	r := Rect{s[0], s[1]}.Normalize()
+	q := Rect{o[0], o[1]}.Normalize()
+	x.X = math.Max(math.Max(r[0].X, q[0].X), math.Min(x.X, math.Min(r[1].X, q[1].X)))
+	x.Y = math.Max(math.Max(r[0].Y, q[0].Y), math.Min(x.Y, math.Min(r[1].Y, q[1].Y)))
+	return x, true
+}
+
+// sweepQueue is a min-heap of event points in sweep order.
+type sweepQueue []Point
+
+func (q sweepQueue) Len() int            { return len(q) }
+func (q sweepQueue) Less(i, j int) bool  { return sweepBefore(q[i], q[j]) }
+func (q sweepQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *sweepQueue) Push(x interface{}) { *q = append(*q, x.(Point)) }
+func (q *sweepQueue) Pop() interface{} {
+	old := *q
+	ret := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ret
+}
@@ -22,3 +22,8 @@ func (t Tri) Facing() int {
 func (t Tri) Area() float64 {
 	return Poly(t[:]).Area()
 }
+
+// Bounds returns the smallest normalized rectangle containing the triangle.
+func (t Tri) Bounds() Rect {
+	return BoundingRect(t[:])
+}
@@ -0,0 +1,25 @@
+package geom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTriArea(t *testing.T) {
+	tests := []struct {
+		Tri  Tri
+		Want float64
+	}{
+		{Tri{{0, 0}, {4, 0}, {0, 2}}, 4},
+		{Tri{{0, 0}, {0, 2}, {4, 0}}, 4},
+		{Tri{{0, 0}, {1, 1}, {2, 2}}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Tri), func(t *testing.T) {
+			if got := test.Tri.Area(); got != test.Want {
+				t.Errorf("wrong area %#v; want %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,538 @@
+package geom
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// Defect describes a problem with a polygon that makes it not simple.
+type Defect struct {
+	// Kind is the kind of problem.
+	Kind DefectKind
+
+	// Point is where the problem occurs.
+	Point Point
+
+	// Edges are the indices of the edges involved in the problem, where
+	// edge i runs from vertex i to the following vertex. Defects involving
+	// only one edge or vertex have -1 as their second index.
+	//
+	// For defects of kind RepeatedVertex and Spike, these are instead the
+	// indices of the vertices involved.
+	Edges [2]int
+}
+
+// DefectKind identifies a kind of Defect.
+type DefectKind int
+
+const (
+	// TooFewVertices indicates a polygon with fewer than three distinct
+	// vertices, which therefore encloses no area.
+	TooFewVertices DefectKind = iota + 1
+
+	// ZeroLengthEdge indicates an edge whose start and end are the same,
+	// due to two consecutive vertices being equal.
+	ZeroLengthEdge
+
+	// RepeatedVertex indicates a vertex that is equal to a non-consecutive
+	// vertex, where the polygon touches itself.
+	RepeatedVertex
+
+	// Spike indicates a vertex where the polygon turns back on itself, so
+	// that its two adjacent edges overlap.
+	Spike
+
+	// SelfIntersection indicates two non-adjacent edges that cross or
+	// touch one another.
+	SelfIntersection
+)
+
+func (k DefectKind) String() string {
+	switch k {
+	case TooFewVertices:
+		return "TooFewVertices"
+	case ZeroLengthEdge:
+		return "ZeroLengthEdge"
+	case RepeatedVertex:
+		return "RepeatedVertex"
+	case Spike:
+		return "Spike"
+	case SelfIntersection:
+		return "SelfIntersection"
+	default:
+		return "DefectKind(?)"
+	}
+}
+
+// IsSimple returns true if the receiving polygon has no defects, as
+// determined by Validate.
+//
+// Only simple polygons produce meaningful results from most of the
+// operations on Poly.
+func (p Poly) IsSimple() bool {
+	return len(p.Validate()) == 0
+}
+
+// Validate checks whether the receiving polygon is simple, returning a
+// description of each of the defects found. The result is empty for a
+// simple polygon.
+//
+// Self-intersections are found using a Bentley-Ottmann sweep, and so
+// validation takes O((n+k) log n) time for a polygon with n vertices and k
+// intersections. Each intersecting pair of edges is reported only once,
+// even if they overlap along their length.
+func (p Poly) Validate() []Defect {
+	var ret []Defect
+
+	distinct := make(map[Point]int, len(p))
+	for i, v := range p {
+		j := (i + 1) % len(p)
+		if v == p[j] {
+			if i != j {
+				ret = append(ret, Defect{ZeroLengthEdge, v, [2]int{i, -1}})
+			}
+			continue
+		}
+		if first, ok := distinct[v]; ok {
+			ret = append(ret, Defect{RepeatedVertex, v, [2]int{first, i}})
+		} else {
+			distinct[v] = i
+		}
+	}
+	if len(distinct) < 3 {
+		// Zero-length edges are implied by too few vertices, so we'll
+		// report only this more general problem.
+		return []Defect{{TooFewVertices, Point{}, [2]int{-1, -1}}}
+	}
+
+	prev, next := p.edgeLinks()
+	for i, v := range p {
+		if next[i] < 0 {
+			continue // zero-length edge
+		}
+		a, b := p[prev[i]], p[next[i]]
+		if orient(a, v, b) == 0 && a.Sub(v).Dot(b.Sub(v)) > 0 {
+			ret = append(ret, Defect{Spike, v, [2]int{i, -1}})
+		}
+	}
+
+	var crossings []Defect
+	p.sweep(func(i, j int, x Point) {
+		if next[i] == j || next[j] == i {
+			// Adjacent edges always meet at their shared vertex, and any
+			// overlap between them is already reported as a spike.
+			return
+		}
+		if (x == p[i] || x == p[(i+1)%len(p)]) && (x == p[j] || x == p[(j+1)%len(p)]) {
+			// Edges meeting at their endpoints were already reported as
+			// a repeated vertex, unless they also overlap.
+			xs := LineSeg{p[i], p[(i+1)%len(p)]}.IntersectLineSeg(LineSeg{p[j], p[(j+1)%len(p)]})
+			if len(xs) < 2 {
+				return
+			}
+			if x == xs[0].Point {
+				x = xs[1].Point
+			} else {
+				x = xs[0].Point
+			}
+		}
+		crossings = append(crossings, Defect{SelfIntersection, x, [2]int{i, j}})
+	})
+	sort.Slice(crossings, func(a, b int) bool {
+		ea, eb := crossings[a].Edges, crossings[b].Edges
+		if ea[0] != eb[0] {
+			return ea[0] < eb[0]
+		}
+		return ea[1] < eb[1]
+	})
+	return append(ret, crossings...)
+}
+
+// SplitSimple repairs a polygon that isn't simple by splitting it into
+// separate simple polygons at each point where it touches or crosses
+// itself, discarding any spikes and any parts that enclose no area.
+//
+// The result has a polygon for each of the areas that the receiver's
+// boundary divides the plane into that the receiver winds around a non-zero
+// number of times, and so the results don't overlap one another. For
+// example, a pentagram is split into its five points and the pentagon in
+// its center.
+//
+// Each resulting polygon retains the direction of travel of the part of the
+// receiver it came from, and so for example the two halves of a
+// figure-eight have opposite facing. If the receiver is already simple
+// then the result is a single copy of it.
+func (p Poly) SplitSimple() []Poly {
+	if p.IsSimple() {
+		return []Poly{append(Poly(nil), p...)}
+	}
+
+	// We start by adding a vertex at each point where two edges intersect,
+	// so that each such point appears at least twice in the ring.
+	// Where more than two edges cross at the same point, rounding errors
+	// may place each pairwise intersection slightly differently, so we snap
+	// together points that are very close to one another.
+	snap := newPointSnapper(p, 1e-10)
+	for _, v := range p {
+		snap.snap(v)
+	}
+	splits := make([][]Point, len(p))
+	p.sweep(func(i, j int, _ Point) {
+		si := LineSeg{p[i], p[(i+1)%len(p)]}
+		sj := LineSeg{p[j], p[(j+1)%len(p)]}
+		for _, hit := range si.IntersectLineSeg(sj) {
+			x := snap.snap(hit.Point)
+			splits[i] = append(splits[i], x)
+			splits[j] = append(splits[j], x)
+		}
+	})
+	ring := make([]Point, 0, len(p))
+	for i, v := range p {
+		ring = append(ring, v)
+		s := splits[i]
+		if len(s) == 0 {
+			continue
+		}
+		sort.Slice(s, func(a, b int) bool {
+			return s[a].Sub(v).LenSq() < s[b].Sub(v).LenSq()
+		})
+		ring = append(ring, s...)
+	}
+	ring = dedupeRing(ring)
+	if len(ring) < 3 {
+		return nil
+	}
+
+	// The edges of the ring now meet only at its vertices, and so they
+	// form a planar graph whose faces are the parts we're looking for.
+	var ret []Poly
+	for _, face := range ringFaces(ring) {
+		ret = splitLoops(ret, face)
+	}
+	return ret
+}
+
+// ringFaces returns the boundaries of the faces of the planar graph formed
+// by the edges of the given ring, whose edges must meet only at its
+// vertices, for each face that the ring winds around a non-zero number of
+// times.
+//
+// Each boundary runs in the same direction as the ring does around the
+// face, starting from the first vertex of the ring that is on it. The
+// boundaries are in the order that a walk around the ring would last visit
+// them.
+func ringFaces(ring []Point) [][]Point {
+	// Each distinct vertex of the ring is a vertex of the graph, and
+	// each pair of vertices joined by one or more edges of the ring is an
+	// edge of the graph. Edge e has the half-edges 2e, running from
+	// ends[e][0] to ends[e][1], and 2e+1 running the other way.
+	vertex := make(map[Point]int, len(ring))
+	var pts []Point
+	for _, v := range ring {
+		if _, ok := vertex[v]; !ok {
+			vertex[v] = len(pts)
+			pts = append(pts, v)
+		}
+	}
+	edgeIndex := make(map[[2]int]int, len(ring))
+	var ends [][2]int
+	var count []int       // net number of ring edges from ends[e][0] to ends[e][1]
+	var ringEdges [][]int // the indices of the ring edges along each edge
+	for i, v := range ring {
+		a, b := vertex[v], vertex[ring[(i+1)%len(ring)]]
+		dir := 1
+		if a > b {
+			a, b, dir = b, a, -1
+		}
+		e, ok := edgeIndex[[2]int{a, b}]
+		if !ok {
+			e = len(ends)
+			edgeIndex[[2]int{a, b}] = e
+			ends = append(ends, [2]int{a, b})
+			count = append(count, 0)
+			ringEdges = append(ringEdges, nil)
+		}
+		count[e] += dir
+		ringEdges[e] = append(ringEdges[e], i)
+	}
+	from := func(h int) int { return ends[h/2][h%2] }
+	to := func(h int) int { return ends[h/2][1-h%2] }
+	weight := func(h int) int {
+		if h%2 == 1 {
+			return -count[h/2]
+		}
+		return count[h/2]
+	}
+
+	// The half-edges leaving each vertex are sorted anti-clockwise, so
+	// that the half-edge following h around the face to its left is the
+	// one just clockwise of h's twin.
+	out := make([][]int, len(pts))
+	for h := 0; h < 2*len(ends); h++ {
+		out[from(h)] = append(out[from(h)], h)
+	}
+	pos := make([]int, 2*len(ends))
+	for _, hs := range out {
+		sort.SliceStable(hs, func(i, j int) bool {
+			return pts[to(hs[i])].Sub(pts[from(hs[i])]).Angle() < pts[to(hs[j])].Sub(pts[from(hs[j])]).Angle()
+		})
+		for i, h := range hs {
+			pos[h] = i
+		}
+	}
+	next := func(h int) int {
+		twin := h ^ 1
+		hs := out[to(h)]
+		return hs[(pos[twin]+len(hs)-1)%len(hs)]
+	}
+
+	face := make([]int, 2*len(ends))
+	for h := range face {
+		face[h] = -1
+	}
+	var cycles [][]int
+	var areas []float64
+	for h := range face {
+		if face[h] >= 0 {
+			continue
+		}
+		var cycle []int
+		var area float64
+		for k := h; face[k] < 0; k = next(k) {
+			face[k] = len(cycles)
+			cycle = append(cycle, k)
+			area += pts[from(k)].Cross(pts[to(k)])
+		}
+		cycles = append(cycles, cycle)
+		areas = append(areas, area)
+	}
+
+	// The ring is connected, so the only face with a clockwise boundary is
+	// the unbounded one outside of everything, whose winding number is
+	// zero. The winding number increases by the weight of each half-edge
+	// when crossing from its right to its left.
+	outer := 0
+	for f, a := range areas {
+		if a < areas[outer] {
+			outer = f
+		}
+	}
+	if areas[outer] >= 0 {
+		return nil
+	}
+	winding := make([]int, len(cycles))
+	known := make([]bool, len(cycles))
+	known[outer] = true
+	queue := []int{outer}
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		for _, h := range cycles[f] {
+			g := face[h^1]
+			if known[g] {
+				continue
+			}
+			known[g] = true
+			winding[g] = winding[f] - weight(h)
+			queue = append(queue, g)
+		}
+	}
+
+	type result struct {
+		pts        []Point
+		first, end int
+	}
+	var results []result
+	for f, cycle := range cycles {
+		if areas[f] <= 0 || winding[f] == 0 {
+			continue
+		}
+		first, last := len(ring), -1
+		for _, h := range cycle {
+			for _, i := range ringEdges[h/2] {
+				first = min(first, i)
+				last = max(last, i)
+			}
+		}
+		boundary := make([]Point, len(cycle))
+		for i, h := range cycle {
+			boundary[i] = pts[from(h)]
+		}
+		if winding[f] < 0 {
+			slices.Reverse(boundary)
+		}
+		start := slices.Index(boundary, ring[first])
+		boundary = append(boundary[start:], boundary[:start]...)
+		results = append(results, result{boundary, first, last})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].end != results[j].end {
+			return results[i].end < results[j].end
+		}
+		return results[i].first < results[j].first
+	})
+	ret := make([][]Point, len(results))
+	for i, r := range results {
+		ret[i] = r.pts
+	}
+	return ret
+}
+
+// splitLoops walks around the given ring, and each time it returns to a
+// point it visited before it cuts off the loop since that visit, appending
+// each loop to polys as described for appendSimpleLoop.
+func splitLoops(polys []Poly, ring []Point) []Poly {
+	var stack []Point
+	seen := make(map[Point]int, len(ring))
+	for _, v := range ring {
+		if at, ok := seen[v]; ok {
+			loop := make(Poly, len(stack)-at)
+			copy(loop, stack[at:])
+			for _, lv := range loop[1:] {
+				delete(seen, lv)
+			}
+			stack = stack[:at+1]
+			polys = appendSimpleLoop(polys, loop)
+			continue
+		}
+		seen[v] = len(stack)
+		stack = append(stack, v)
+	}
+	return appendSimpleLoop(polys, Poly(stack))
+}
+
+// appendSimpleLoop appends the given loop to polys after removing any spikes,
+// unless the loop encloses no area.
+func appendSimpleLoop(polys []Poly, loop Poly) []Poly {
+	for {
+		loop = Poly(dedupeRing(loop))
+		if len(loop) < 3 {
+			return polys
+		}
+		prev, next := loop.edgeLinks()
+		spike := -1
+		for i, v := range loop {
+			a, b := loop[prev[i]], loop[next[i]]
+			if orient(a, v, b) == 0 && a.Sub(v).Dot(b.Sub(v)) >= 0 {
+				spike = i
+				break
+			}
+		}
+		if spike < 0 {
+			break
+		}
+		loop = append(loop[:spike], loop[spike+1:]...)
+	}
+	if loop.orientation() == 0 {
+		return polys
+	}
+	return append(polys, loop)
+}
+
+// dedupeRing removes any consecutive duplicate points from a closed ring,
+// including between the last and first points.
+func dedupeRing(ring []Point) []Point {
+	ret := ring[:0:0]
+	for i, v := range ring {
+		if i > 0 && v == ring[i-1] {
+			continue
+		}
+		ret = append(ret, v)
+	}
+	for len(ret) > 1 && ret[len(ret)-1] == ret[0] {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
+// edgeLinks returns, for each vertex, the index of the nearest preceding and
+// following vertices that are not equal to it. Both are -1 for a vertex that
+// is followed by an equal vertex.
+func (p Poly) edgeLinks() (prev, next []int) {
+	n := len(p)
+	prev = make([]int, n)
+	next = make([]int, n)
+	for i, v := range p {
+		next[i], prev[i] = -1, -1
+		if v == p[(i+1)%n] {
+			continue
+		}
+		for k := 1; k < n; k++ {
+			if j := (i + k) % n; p[j] != v {
+				next[i] = j
+				break
+			}
+		}
+		for k := 1; k < n; k++ {
+			if j := (i - k + n) % n; p[j] != v {
+				prev[i] = j
+				break
+			}
+		}
+	}
+
+	// The edge that ends a run of equal vertices is really the edge
+	// from the last vertex in the run, so we find the next edge from there.
+	for i := range p {
+		if next[i] < 0 {
+			continue
+		}
+		j := next[i]
+		for k := 0; k < n && p[(j+1)%n] == p[j]; k++ {
+			j = (j + 1) % n
+		}
+		next[i] = j
+	}
+	return prev, next
+}
+
+// sweep finds all pairs of edges of the receiver that intersect, using
+// sweepIntersections.
+func (p Poly) sweep(report func(i, j int, x Point)) {
+	segs := make([]LineSeg, len(p))
+	for i, v := range p {
+		segs[i] = LineSeg{v, p[(i+1)%len(p)]}
+	}
+	sweepIntersections(segs, report)
+}
+
+// pointSnapper replaces points with any earlier point that lies within a
+// small distance of them.
+type pointSnapper struct {
+	eps   float64
+	cells map[[2]int64][]Point
+}
+
+// newPointSnapper returns a pointSnapper whose tolerance is the given
+// fraction of the largest coordinate among the given points.
+func newPointSnapper(pts []Point, rel float64) *pointSnapper {
+	var scale float64
+	for _, v := range pts {
+		scale = math.Max(scale, math.Max(math.Abs(v.X), math.Abs(v.Y)))
+	}
+	eps := scale * rel
+	if eps == 0 {
+		eps = rel
+	}
+	return &pointSnapper{
+		eps:   eps,
+		cells: make(map[[2]int64][]Point),
+	}
+}
+
+func (s *pointSnapper) snap(p Point) Point {
+	cx, cy := int64(math.Floor(p.X/s.eps)), int64(math.Floor(p.Y/s.eps))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, q := range s.cells[[2]int64{cx + dx, cy + dy}] {
+				if math.Abs(q.X-p.X) <= s.eps && math.Abs(q.Y-p.Y) <= s.eps {
+					return q
+				}
+			}
+		}
+	}
+	k := [2]int64{cx, cy}
+	s.cells[k] = append(s.cells[k], p)
+	return p
+}
@@ -0,0 +1,167 @@
+package geom
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPolyValidate(t *testing.T) {
+	tests := []struct {
+		Poly Poly
+		Want []Defect
+	}{
+		{
+			Poly{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+			nil,
+		},
+		{
+			Poly{{0, 0}, {2, 0}, {2, 0}},
+			[]Defect{{TooFewVertices, Point{}, [2]int{-1, -1}}},
+		},
+		{
+			Poly{{0, 0}, {2, 0}, {2, 0}, {2, 2}},
+			[]Defect{{ZeroLengthEdge, Point{2, 0}, [2]int{1, -1}}},
+		},
+		{
+			// A figure-eight, whose second and fourth edges cross.
+			Poly{{0, 0}, {2, 0}, {0, 2}, {2, 2}},
+			[]Defect{{SelfIntersection, Point{1, 1}, [2]int{1, 3}}},
+		},
+		{
+			// Two squares touching at a corner.
+			Poly{{0, 0}, {2, 0}, {2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}, {0, 2}},
+			[]Defect{{RepeatedVertex, Point{2, 2}, [2]int{2, 6}}},
+		},
+		{
+			// A square with a spike sticking out of its top edge.
+			Poly{{0, 0}, {2, 0}, {2, 2}, {1, 2}, {1, 3}, {1, 2}, {0, 2}},
+			[]Defect{
+				{RepeatedVertex, Point{1, 2}, [2]int{3, 5}},
+				{Spike, Point{1, 3}, [2]int{4, -1}},
+			},
+		},
+		{
+			// A vertex touching the middle of a non-adjacent edge.
+			Poly{{0, 0}, {4, 0}, {4, 4}, {2, 0}, {0, 4}},
+			[]Defect{
+				{SelfIntersection, Point{2, 0}, [2]int{0, 2}},
+				{SelfIntersection, Point{2, 0}, [2]int{0, 3}},
+			},
+		},
+		{
+			// A vertical edge crossing a horizontal one.
+			Poly{{0, 1}, {2, 1}, {1, 2}, {1, 0}},
+			[]Defect{{SelfIntersection, Point{1, 1}, [2]int{0, 2}}},
+		},
+		{
+			// A vertical edge crossing a sloped one at a point that can't
+			// be represented exactly.
+			Poly{{0, 0}, {3, 1}, {1, 3}, {1, -1}},
+			[]Defect{{SelfIntersection, Point{1, 1.0 / 3}, [2]int{0, 2}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Poly), func(t *testing.T) {
+			got := test.Poly.Validate()
+
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+			if got, want := test.Poly.IsSimple(), len(test.Want) == 0; got != want {
+				t.Errorf("IsSimple returned %#v; want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestPolySplitSimple(t *testing.T) {
+	tests := []struct {
+		Poly Poly
+		Want []Poly
+	}{
+		{
+			Poly{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+			[]Poly{{{0, 0}, {2, 0}, {2, 2}, {0, 2}}},
+		},
+		{
+			Poly{{0, 0}, {2, 0}, {0, 2}, {2, 2}},
+			[]Poly{
+				{{1, 1}, {0, 2}, {2, 2}},
+				{{0, 0}, {2, 0}, {1, 1}},
+			},
+		},
+		{
+			Poly{{0, 0}, {2, 0}, {2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}, {0, 2}},
+			[]Poly{
+				{{2, 2}, {4, 2}, {4, 4}, {2, 4}},
+				{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+			},
+		},
+		{
+			Poly{{0, 0}, {2, 0}, {2, 2}, {1, 2}, {1, 3}, {1, 2}, {0, 2}},
+			[]Poly{{{0, 0}, {2, 0}, {2, 2}, {1, 2}, {0, 2}}},
+		},
+		{
+			Poly{{0, 0}, {2, 0}, {2, 0}, {0, 0}},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Poly), func(t *testing.T) {
+			got := test.Poly.SplitSimple()
+
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+			for i, p := range got {
+				if !p.IsSimple() {
+					t.Errorf("result %d is not simple: %#v", i, p.Validate())
+				}
+			}
+		})
+	}
+}
+
+func TestPolyValidatePentagram(t *testing.T) {
+	// A pentagram has five crossings, whatever its orientation, and splits
+	// into its five points along with the pentagon at its center.
+	for deg := 0; deg < 360; deg += 3 {
+		t.Run(fmt.Sprintf("%d", deg), func(t *testing.T) {
+			p := make(Poly, 5)
+			for i := range p {
+				a := float64(deg)*math.Pi/180 + float64(2*i%5)*2*math.Pi/5
+				p[i] = Point{math.Cos(a), math.Sin(a)}
+			}
+
+			var crossings int
+			for _, d := range p.Validate() {
+				if d.Kind == SelfIntersection {
+					crossings++
+				}
+			}
+			if got, want := crossings, 5; got != want {
+				t.Errorf("found %d crossings; want %d", got, want)
+			}
+
+			parts := p.SplitSimple()
+			if got, want := len(parts), 6; got != want {
+				t.Errorf("got %d parts; want %d", got, want)
+			}
+			var area float64
+			for i, part := range parts {
+				if !part.IsSimple() {
+					t.Errorf("result %d is not simple: %#v", i, part.Validate())
+				}
+				area += part.Area()
+			}
+			if want := 1.1225699414489640; math.Abs(area-want) > 1e-9 {
+				t.Errorf("parts have total area %v; want %v", area, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package pointindex provides spatial indexes of points, which can quickly
+// find the points nearest to a given location or within a given area.
+//
+// Each index identifies points by their position in the slice the index was
+// built from, so that callers can easily relate the results to any other
+// data they hold about each point.
+package pointindex
@@ -0,0 +1,98 @@
+package pointindex
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Index is implemented by each of the spatial indexes in this package.
+type Index interface {
+	// Nearest returns the index of the point closest to p. The second
+	// result is false if there are no points at all.
+	Nearest(p geom.Point) (int, bool)
+
+	// KNearest returns the indices of up to k points closest to p, in order
+	// of increasing distance.
+	KNearest(p geom.Point, k int) []int
+
+	// Radius returns the indices of all of the points whose distance from p
+	// is no greater than r, in increasing order.
+	Radius(p geom.Point, r float64) []int
+
+	// Range returns the indices of all of the points inside the given
+	// rectangle or on its edges, in increasing order.
+	Range(r geom.Rect) []int
+}
+
+// Points are ordered by distance and then by index, so that results are
+// deterministic even when several points are the same distance away.
+type neighbor struct {
+	dist float64
+	id   int
+}
+
+func (n neighbor) closer(o neighbor) bool {
+	return n.dist < o.dist || (n.dist == o.dist && n.id < o.id)
+}
+
+// neighbors is a max-heap of the k closest points found so far during a
+// nearest-neighbor search.
+type neighbors struct {
+	k     int
+	items []neighbor
+}
+
+func (h *neighbors) full() bool {
+	return len(h.items) >= h.k
+}
+
+// worst returns the squared distance of the furthest point found so far.
+// It must only be called when the heap is full.
+func (h *neighbors) worst() float64 {
+	return h.items[0].dist
+}
+
+func (h *neighbors) offer(id int, dist float64) {
+	n := neighbor{dist, id}
+	if !h.full() {
+		heap.Push(h, n)
+		return
+	}
+	if n.closer(h.items[0]) {
+		h.items[0] = n
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns the indices of the points found, closest first.
+func (h *neighbors) sorted() []int {
+	sort.Slice(h.items, func(i, j int) bool {
+		return h.items[i].closer(h.items[j])
+	})
+	ret := make([]int, len(h.items))
+	for i, n := range h.items {
+		ret[i] = n.id
+	}
+	return ret
+}
+
+func (h *neighbors) Len() int           { return len(h.items) }
+func (h *neighbors) Less(i, j int) bool { return h.items[j].closer(h.items[i]) }
+func (h *neighbors) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *neighbors) Push(x interface{}) { h.items = append(h.items, x.(neighbor)) }
+func (h *neighbors) Pop() interface{} {
+	ret := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return ret
+}
+
+// rectDistSq returns the squared distance from p to the nearest point in
+// the normalized rectangle r.
+func rectDistSq(r geom.Rect, p geom.Point) float64 {
+	dx := math.Max(0, math.Max(r[0].X-p.X, p.X-r[1].X))
+	dy := math.Max(0, math.Max(r[0].Y-p.Y, p.Y-r[1].Y))
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,137 @@
+package pointindex
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+	"github.com/go-test/deep"
+)
+
+func TestIndexes(t *testing.T) {
+	pts := []geom.Point{
+		{0, 0},
+		{10, 0},
+		{0, 10},
+		{10, 10},
+		{5, 5},
+		{5, 5}, // duplicate
+		{-3, 4},
+	}
+	indexes := map[string]Index{
+		"KDTree":   NewKDTree(pts),
+		"Quadtree": NewQuadtree(pts),
+	}
+
+	for name, idx := range indexes {
+		t.Run(name, func(t *testing.T) {
+			if got, ok := idx.Nearest(geom.Point{9, 8}); !ok || got != 3 {
+				t.Errorf("wrong nearest %d, %#v; want 3, true", got, ok)
+			}
+			for _, problem := range deep.Equal(idx.KNearest(geom.Point{5, 4}, 3), []int{4, 5, 0}) {
+				t.Errorf("KNearest: %s", problem)
+			}
+			for _, problem := range deep.Equal(idx.Radius(geom.Point{0, 0}, 5), []int{0, 6}) {
+				t.Errorf("Radius: %s", problem)
+			}
+			for _, problem := range deep.Equal(idx.Range(geom.Rect{{10, 10}, {5, 0}}), []int{1, 3, 4, 5}) {
+				t.Errorf("Range: %s", problem)
+			}
+		})
+	}
+
+	for name, idx := range map[string]Index{"KDTree": NewKDTree(nil), "Quadtree": NewQuadtree(nil)} {
+		t.Run(fmt.Sprintf("empty %s", name), func(t *testing.T) {
+			if _, ok := idx.Nearest(geom.Point{}); ok {
+				t.Errorf("found a nearest point in an empty index")
+			}
+			if got := idx.KNearest(geom.Point{}, 2); len(got) != 0 {
+				t.Errorf("found nearest points %#v in an empty index", got)
+			}
+		})
+	}
+}
+
+func TestQuadtreeInsert(t *testing.T) {
+	tree := NewQuadtree([]geom.Point{{0, 0}, {1, 1}})
+
+	// Points far outside the original bounds cause the tree to grow.
+	if got := tree.Insert(geom.Point{-100, 50}); got != 2 {
+		t.Errorf("wrong index %d for inserted point; want 2", got)
+	}
+	tree.Insert(geom.Point{1e6, -1e6})
+
+	if got, _ := tree.Nearest(geom.Point{-90, 40}); got != 2 {
+		t.Errorf("wrong nearest %d; want 2", got)
+	}
+	for _, problem := range deep.Equal(tree.Range(geom.Rect{{-1, -1}, {2, 2}}), []int{0, 1}) {
+		t.Error(problem)
+	}
+	if got := tree.Len(); got != 4 {
+		t.Errorf("wrong length %d; want 4", got)
+	}
+}
+
+// TestIndexesRandom compares the results of each index with those of a
+// linear scan.
+func TestIndexesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pts := make([]geom.Point, 5000)
+	for i := range pts {
+		if i%10 == 0 {
+			// Include some repeated coordinates, which are awkward cases
+			// for both kinds of tree.
+			pts[i] = geom.Point{float64(r.Intn(10)) * 100, float64(r.Intn(10)) * 100}
+			continue
+		}
+		pts[i] = geom.Point{r.Float64() * 1000, r.Float64() * 1000}
+	}
+
+	quad := NewQuadtree(pts[:len(pts)/2])
+	for _, p := range pts[len(pts)/2:] {
+		quad.Insert(p)
+	}
+	indexes := map[string]Index{
+		"KDTree":   NewKDTree(pts),
+		"Quadtree": quad,
+	}
+
+	for name, idx := range indexes {
+		t.Run(name, func(t *testing.T) {
+			for q := 0; q < 200; q++ {
+				p := geom.Point{r.Float64()*1200 - 100, r.Float64()*1200 - 100}
+
+				byDist := make([]int, len(pts))
+				for i := range byDist {
+					byDist[i] = i
+				}
+				sort.Slice(byDist, func(i, j int) bool {
+					di, dj := p.Sub(pts[byDist[i]]).LenSq(), p.Sub(pts[byDist[j]]).LenSq()
+					return di < dj || (di == dj && byDist[i] < byDist[j])
+				})
+				for _, problem := range deep.Equal(idx.KNearest(p, 10), byDist[:10]) {
+					t.Errorf("KNearest(%#v): %s", p, problem)
+				}
+
+				var radius, rng []int
+				bounds := geom.Rect{p, p.Add(geom.Point{50, 80})}
+				for i, v := range pts {
+					if p.Sub(v).LenSq() <= 40*40 {
+						radius = append(radius, i)
+					}
+					if bounds.Contains(v) {
+						rng = append(rng, i)
+					}
+				}
+				for _, problem := range deep.Equal(idx.Radius(p, 40), radius) {
+					t.Errorf("Radius(%#v): %s", p, problem)
+				}
+				for _, problem := range deep.Equal(idx.Range(bounds), rng) {
+					t.Errorf("Range(%#v): %s", bounds, problem)
+				}
+			}
+		})
+	}
+}
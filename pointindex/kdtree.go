@@ -0,0 +1,249 @@
+package pointindex
+
+import (
+	"math"
+	"sort"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// KDTree is a static index of a set of points, stored as a balanced k-d
+// tree.
+//
+// A KDTree can't be modified after it's built, but is compact and fast to
+// search. Any number of searches may safely run concurrently.
+type KDTree struct {
+	pts []geom.Point
+
+	// ids is a permutation of the point indices forming an implicit tree.
+	// The subtree over the range [lo, hi) has its root at the midpoint of
+	// the range, with the points before it in the range being on its lower
+	// side along the subtree's axis and the points after it on its upper
+	// side.
+	ids []int
+
+	// axes gives the axis that each subtree is divided along, zero for X
+	// and one for Y, at the same index as the subtree's root in ids. Each
+	// subtree is divided along whichever axis its points are most spread
+	// out on, which copes better than alternating axes with points that
+	// have many coordinates in common.
+	axes []uint8
+}
+
+var _ Index = (*KDTree)(nil)
+
+// NewKDTree builds a k-d tree containing the given points.
+//
+// The tree keeps its own copy of the points, so the caller may modify the
+// given slice afterwards.
+func NewKDTree(pts []geom.Point) *KDTree {
+	t := &KDTree{
+		pts:  make([]geom.Point, len(pts)),
+		ids:  make([]int, len(pts)),
+		axes: make([]uint8, len(pts)),
+	}
+	copy(t.pts, pts)
+	for i := range t.ids {
+		t.ids[i] = i
+	}
+	t.build(0, len(t.ids))
+	return t
+}
+
+func (t *KDTree) build(lo, hi int) {
+	if hi-lo <= 1 {
+		return
+	}
+	min, max := t.pts[t.ids[lo]], t.pts[t.ids[lo]]
+	for _, id := range t.ids[lo+1 : hi] {
+		p := t.pts[id]
+		min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+		max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+	}
+	axis := 0
+	if max.Y-min.Y > max.X-min.X {
+		axis = 1
+	}
+
+	m := (lo + hi) / 2
+	t.selectNth(lo, hi, m, axis)
+	t.axes[m] = uint8(axis)
+	t.build(lo, m)
+	t.build(m+1, hi)
+}
+
+// selectNth rearranges ids[lo:hi] so that the element at n is the one that
+// would be there if the range were sorted by the given axis, with no
+// greater elements before it and no smaller elements after it.
+func (t *KDTree) selectNth(lo, hi, n, axis int) {
+	less := func(i, j int) bool {
+		return t.coord(t.ids[i], axis) < t.coord(t.ids[j], axis)
+	}
+	hi-- // use an inclusive range from here on
+	for hi > lo {
+		if hi-lo < 16 {
+			sort.Slice(t.ids[lo:hi+1], func(i, j int) bool {
+				return less(lo+i, lo+j)
+			})
+			return
+		}
+
+		// Partition around the median of three elements, which avoids
+		// the worst case for inputs that are already sorted.
+		mid := (lo + hi) / 2
+		if less(mid, lo) {
+			t.ids[mid], t.ids[lo] = t.ids[lo], t.ids[mid]
+		}
+		if less(hi, lo) {
+			t.ids[hi], t.ids[lo] = t.ids[lo], t.ids[hi]
+		}
+		if less(hi, mid) {
+			t.ids[hi], t.ids[mid] = t.ids[mid], t.ids[hi]
+		}
+		pivot := t.coord(t.ids[mid], axis)
+		i, j := lo, hi
+		for i <= j {
+			for t.coord(t.ids[i], axis) < pivot {
+				i++
+			}
+			for t.coord(t.ids[j], axis) > pivot {
+				j--
+			}
+			if i <= j {
+				t.ids[i], t.ids[j] = t.ids[j], t.ids[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case n <= j:
+			hi = j
+		case n >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
+
+func (t *KDTree) coord(id, axis int) float64 {
+	if axis == 0 {
+		return t.pts[id].X
+	}
+	return t.pts[id].Y
+}
+
+// Len returns the number of points in the tree.
+func (t *KDTree) Len() int {
+	return len(t.pts)
+}
+
+// Nearest returns the index of the point closest to p. The second result
+// is false if the tree is empty.
+//
+// If several points are equally close then the one with the smallest index
+// is returned.
+func (t *KDTree) Nearest(p geom.Point) (int, bool) {
+	ret := t.KNearest(p, 1)
+	if len(ret) == 0 {
+		return 0, false
+	}
+	return ret[0], true
+}
+
+// KNearest returns the indices of up to k points closest to p, in order of
+// increasing distance. Points at equal distances are ordered by index.
+func (t *KDTree) KNearest(p geom.Point, k int) []int {
+	if k <= 0 {
+		return nil
+	}
+	h := &neighbors{k: k}
+	t.nearest(0, len(t.ids), p, h, 0, &[2]float64{})
+	return h.sorted()
+}
+
+// nearest offers the points in a subtree to h. The region of the plane
+// covered by the subtree is at squared distance dist from p, and off is the
+// offset from p to that region along each axis.
+func (t *KDTree) nearest(lo, hi int, p geom.Point, h *neighbors, dist float64, off *[2]float64) {
+	if lo >= hi {
+		return
+	}
+	m := (lo + hi) / 2
+	id, axis := t.ids[m], int(t.axes[m])
+	h.offer(id, p.Sub(t.pts[id]).LenSq())
+
+	// We search the side containing p first, since it's more likely to
+	// contain close points that allow us to skip the other side entirely.
+	d := t.coord(id, axis)
+	if axis == 0 {
+		d = p.X - d
+	} else {
+		d = p.Y - d
+	}
+	nearLo, nearHi, farLo, farHi := lo, m, m+1, hi
+	if d >= 0 {
+		nearLo, nearHi, farLo, farHi = m+1, hi, lo, m
+	}
+	t.nearest(nearLo, nearHi, p, h, dist, off)
+
+	// The other side is further from p along this axis, but no different
+	// along the other.
+	old := off[axis]
+	farDist := dist - old*old + d*d
+	if !h.full() || farDist <= h.worst() {
+		off[axis] = d
+		t.nearest(farLo, farHi, p, h, farDist, off)
+		off[axis] = old
+	}
+}
+
+// Radius returns the indices of all of the points whose distance from p is
+// no greater than r, in increasing order.
+func (t *KDTree) Radius(p geom.Point, r float64) []int {
+	bounds := geom.Rect{{X: p.X - r, Y: p.Y - r}, {X: p.X + r, Y: p.Y + r}}
+	var ret []int
+	t.visit(0, len(t.ids), bounds, func(id int) {
+		if p.Sub(t.pts[id]).LenSq() <= r*r {
+			ret = append(ret, id)
+		}
+	})
+	sort.Ints(ret)
+	return ret
+}
+
+// Range returns the indices of all of the points inside the given rectangle
+// or on its edges, in increasing order. The rectangle need not be
+// normalized.
+func (t *KDTree) Range(r geom.Rect) []int {
+	var ret []int
+	t.visit(0, len(t.ids), r.Normalize(), func(id int) {
+		ret = append(ret, id)
+	})
+	sort.Ints(ret)
+	return ret
+}
+
+// visit calls fn for each point in the subtree that lies within the given
+// normalized rectangle.
+func (t *KDTree) visit(lo, hi int, r geom.Rect, fn func(id int)) {
+	if lo >= hi {
+		return
+	}
+	m := (lo + hi) / 2
+	id, axis := t.ids[m], int(t.axes[m])
+	if r.Contains(t.pts[id]) {
+		fn(id)
+	}
+	min, max := r[0].X, r[1].X
+	if axis == 1 {
+		min, max = r[0].Y, r[1].Y
+	}
+	c := t.coord(id, axis)
+	if min <= c {
+		t.visit(lo, m, r, fn)
+	}
+	if max >= c {
+		t.visit(m+1, hi, r, fn)
+	}
+}
@@ -0,0 +1,278 @@
+package pointindex
+
+import (
+	"math"
+	"sort"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+const (
+	// quadLeafSize is the number of points a quadtree leaf can hold before
+	// it's divided into four.
+	quadLeafSize = 8
+
+	// quadMaxDepth limits how many times a leaf can be divided, so that
+	// many points at the same position can't cause endless division.
+	quadMaxDepth = 32
+)
+
+// Quadtree is an index of a set of points that recursively divides the
+// plane into square quadrants.
+//
+// Unlike KDTree, a Quadtree can grow by inserting new points. Searches may
+// run concurrently with one another, but not with Insert.
+type Quadtree struct {
+	pts  []geom.Point
+	root *quadNode
+
+	// bounds is the square region covered by the root node. It grows as
+	// necessary to contain all of the points.
+	bounds geom.Rect
+}
+
+var _ Index = (*Quadtree)(nil)
+
+// quadNode is either a leaf, holding the indices of the points within it,
+// or has four children covering the quadrants of its region in the order
+// lower-left, lower-right, upper-left, upper-right.
+type quadNode struct {
+	ids      []int
+	children *[4]quadNode
+}
+
+// NewQuadtree builds a quadtree containing the given points.
+//
+// The tree keeps its own copy of the points, so the caller may modify the
+// given slice afterwards.
+func NewQuadtree(pts []geom.Point) *Quadtree {
+	t := &Quadtree{}
+	if len(pts) > 0 {
+		// Starting with bounds that cover all of the points avoids
+		// growing the tree repeatedly as they are inserted.
+		min, max := pts[0], pts[0]
+		for _, p := range pts[1:] {
+			min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+			max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+		}
+		size := math.Max(max.X-min.X, max.Y-min.Y)
+		if size == 0 {
+			size = 1
+		}
+		t.root = &quadNode{}
+		t.bounds = geom.Rect{min, {X: min.X + size, Y: min.Y + size}}
+	}
+	t.pts = make([]geom.Point, 0, len(pts))
+	for _, p := range pts {
+		t.Insert(p)
+	}
+	return t
+}
+
+// Len returns the number of points in the tree.
+func (t *Quadtree) Len() int {
+	return len(t.pts)
+}
+
+// Insert adds a point to the tree, returning its index. Indices are
+// allocated sequentially, so the first point inserted into an empty tree
+// has index zero.
+func (t *Quadtree) Insert(p geom.Point) int {
+	id := len(t.pts)
+	t.pts = append(t.pts, p)
+
+	if t.root == nil {
+		t.root = &quadNode{}
+		t.bounds = geom.Rect{p, {X: p.X + 1, Y: p.Y + 1}}
+	}
+
+	if !t.bounds.Contains(p) {
+		// The bounds grow by doubling in size towards the new point until
+		// they contain it. We then rebuild the tree from scratch, rather than
+		// making the old root a quadrant of the new one, because rounding
+		// errors may cause the new quadrant's bounds to differ slightly
+		// from the old root's.
+		b := t.bounds
+		for !b.Contains(p) {
+			size := b[1].X - b[0].X
+			if size == 0 {
+				// Adding one to very large coordinates has no effect, so
+				// the initial bounds of a tree may be empty.
+				size = math.Max(math.Abs(p.X-b[0].X), math.Abs(p.Y-b[0].Y))
+			}
+			if p.X < b[0].X {
+				b[0].X -= size
+			} else {
+				b[1].X += size
+			}
+			if p.Y < b[0].Y {
+				b[0].Y -= size
+			} else {
+				b[1].Y += size
+			}
+		}
+		t.bounds = b
+		t.root = &quadNode{}
+		for old := 0; old < id; old++ {
+			t.root.insert(t.pts, old, t.bounds, 0)
+		}
+	}
+
+	t.root.insert(t.pts, id, t.bounds, 0)
+	return id
+}
+
+func (n *quadNode) insert(pts []geom.Point, id int, bounds geom.Rect, depth int) {
+	for n.children != nil {
+		q, qb := quadrant(bounds, pts[id])
+		n, bounds = &n.children[q], qb
+		depth++
+	}
+	n.ids = append(n.ids, id)
+	if len(n.ids) <= quadLeafSize || depth >= quadMaxDepth {
+		return
+	}
+
+	ids := n.ids
+	n.ids = nil
+	n.children = &[4]quadNode{}
+	for _, id := range ids {
+		n.insert(pts, id, bounds, depth)
+	}
+}
+
+// quadrant returns which quadrant of the given bounds contains p, and the
+// bounds of that quadrant.
+func quadrant(bounds geom.Rect, p geom.Point) (int, geom.Rect) {
+	mid := geom.Point{X: (bounds[0].X + bounds[1].X) / 2, Y: (bounds[0].Y + bounds[1].Y) / 2}
+	q := 0
+	if p.X >= mid.X {
+		q |= 1
+	}
+	if p.Y >= mid.Y {
+		q |= 2
+	}
+	return q, quadrantBounds(bounds, q)
+}
+
+func quadrantBounds(bounds geom.Rect, q int) geom.Rect {
+	mid := geom.Point{X: (bounds[0].X + bounds[1].X) / 2, Y: (bounds[0].Y + bounds[1].Y) / 2}
+	ret := geom.Rect{bounds[0], mid}
+	if q&1 != 0 {
+		ret[0].X, ret[1].X = mid.X, bounds[1].X
+	}
+	if q&2 != 0 {
+		ret[0].Y, ret[1].Y = mid.Y, bounds[1].Y
+	}
+	return ret
+}
+
+// Nearest returns the index of the point closest to p. The second result
+// is false if the tree is empty.
+//
+// If several points are equally close then the one with the smallest index
+// is returned.
+func (t *Quadtree) Nearest(p geom.Point) (int, bool) {
+	ret := t.KNearest(p, 1)
+	if len(ret) == 0 {
+		return 0, false
+	}
+	return ret[0], true
+}
+
+// KNearest returns the indices of up to k points closest to p, in order of
+// increasing distance. Points at equal distances are ordered by index.
+func (t *Quadtree) KNearest(p geom.Point, k int) []int {
+	if k <= 0 || t.root == nil {
+		return nil
+	}
+	h := &neighbors{k: k}
+	t.root.nearest(t.pts, t.bounds, p, h)
+	return h.sorted()
+}
+
+// nearest offers the points in the receiver, whose region is given by
+// bounds, to h. Quadrants too far from p to contain any points closer than
+// those h already holds are skipped.
+func (n *quadNode) nearest(pts []geom.Point, bounds geom.Rect, p geom.Point, h *neighbors) {
+	if n.children == nil {
+		for _, id := range n.ids {
+			h.offer(id, p.Sub(pts[id]).LenSq())
+		}
+		return
+	}
+
+	// Visiting the closest quadrants first makes it more likely that we can
+	// skip the others.
+	var order [4]int
+	var qbounds [4]geom.Rect
+	var dists [4]float64
+	for q := range order {
+		order[q] = q
+		qbounds[q] = quadrantBounds(bounds, q)
+		dists[q] = rectDistSq(qbounds[q], p)
+	}
+	for i := 1; i < len(order); i++ {
+		for j := i; j > 0 && dists[order[j]] < dists[order[j-1]]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+	for _, q := range order {
+		if h.full() && dists[q] > h.worst() {
+			break
+		}
+		n.children[q].nearest(pts, qbounds[q], p, h)
+	}
+}
+
+// Radius returns the indices of all of the points whose distance from p is
+// no greater than r, in increasing order.
+func (t *Quadtree) Radius(p geom.Point, r float64) []int {
+	bounds := geom.Rect{{X: p.X - r, Y: p.Y - r}, {X: p.X + r, Y: p.Y + r}}
+	var ret []int
+	t.visit(bounds, func(id int) {
+		if p.Sub(t.pts[id]).LenSq() <= r*r {
+			ret = append(ret, id)
+		}
+	})
+	sort.Ints(ret)
+	return ret
+}
+
+// Range returns the indices of all of the points inside the given rectangle
+// or on its edges, in increasing order. The rectangle need not be
+// normalized.
+func (t *Quadtree) Range(r geom.Rect) []int {
+	var ret []int
+	t.visit(r.Normalize(), func(id int) {
+		ret = append(ret, id)
+	})
+	sort.Ints(ret)
+	return ret
+}
+
+// visit calls fn for each point in the tree that lies within the given
+// normalized rectangle.
+func (t *Quadtree) visit(r geom.Rect, fn func(id int)) {
+	if t.root == nil {
+		return
+	}
+	t.root.visit(t.pts, t.bounds, r, fn)
+}
+
+func (n *quadNode) visit(pts []geom.Point, bounds, r geom.Rect, fn func(id int)) {
+	if !bounds.Overlaps(r) {
+		return
+	}
+	if n.children == nil {
+		for _, id := range n.ids {
+			if r.Contains(pts[id]) {
+				fn(id)
+			}
+		}
+		return
+	}
+	for q := range n.children {
+		n.children[q].visit(pts, quadrantBounds(bounds, q), r, fn)
+	}
+}
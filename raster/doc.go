@@ -0,0 +1,10 @@
+// Package raster converts shapes described using types from package geom
+// into anti-aliased coverage masks, suitable for drawing with package
+// image/draw.
+//
+// Geometry coordinates are interpreted directly as image coordinates, with
+// the Y axis increasing downward and each pixel covering a unit square.
+//
+// Paths from package svgpath can be rasterized after converting them with
+// their GeomPath method.
+package raster
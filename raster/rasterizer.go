@@ -0,0 +1,226 @@
+package raster
+
+import (
+	"image"
+	"image/draw"
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Rasterizer accumulates the edges of filled shapes and then produces a
+// coverage mask for them.
+//
+// The coverage of each pixel is the exact area of the pixel that the shape
+// covers, and so the result is anti-aliased without any supersampling.
+// Where several edges pass through the same pixel the coverage is
+// approximate, since only the net winding is recorded.
+//
+// The zero value of Rasterizer has empty bounds. Use NewRasterizer to create
+// a useful one.
+type Rasterizer struct {
+	bounds image.Rectangle
+
+	// acc has a row of stride values for each row of pixels, each of which
+	// is the change in signed coverage from the pixel to its left. Each row
+	// has two extra values to absorb contributions at or beyond the right
+	// edge.
+	acc    []float32
+	stride int
+}
+
+// NewRasterizer creates a rasterizer for shapes within the given bounds.
+// Parts of shapes outside of the bounds are clipped.
+func NewRasterizer(bounds image.Rectangle) *Rasterizer {
+	bounds = bounds.Canon()
+	stride := bounds.Dx() + 2
+	return &Rasterizer{
+		bounds: bounds,
+		acc:    make([]float32, stride*bounds.Dy()),
+		stride: stride,
+	}
+}
+
+// Bounds returns the bounds that the rasterizer was created with.
+func (r *Rasterizer) Bounds() image.Rectangle {
+	return r.bounds
+}
+
+// Reset discards all of the shapes added so far, so that the rasterizer can
+// be reused.
+func (r *Rasterizer) Reset() {
+	clear(r.acc)
+}
+
+// AddPoly adds a polygon to the shape being filled.
+func (r *Rasterizer) AddPoly(p geom.Poly) {
+	r.addRing(geom.LineSegSeq(p))
+}
+
+// AddLineSegSeq adds a sequence of line segments to the shape being filled.
+// The sequence is implicitly closed with a line back to its start point.
+func (r *Rasterizer) AddLineSegSeq(seq geom.LineSegSeq) {
+	r.addRing(seq)
+}
+
+// AddCubicCurveSeq adds a sequence of curves to the shape being filled,
+// flattening them to the given tolerance in pixels. The sequence is
+// implicitly closed with a line back to its start point.
+func (r *Rasterizer) AddCubicCurveSeq(seq geom.CubicCurveSeq, tolerance float64) {
+	r.addRing(seq.Flatten(tolerance))
+}
+
+// AddPath adds all of the contours of a path to the shape being filled,
+// flattening any curves to the given tolerance in pixels. Each contour is
+// implicitly closed, whether or not it is closed in the path.
+func (r *Rasterizer) AddPath(p geom.Path, tolerance float64) {
+	for _, c := range p {
+		r.addRing(c.Flatten(tolerance))
+	}
+}
+
+// AddLineSeg adds a single edge to the shape being filled.
+//
+// A shape made of individual edges is filled correctly only if the edges
+// together form closed loops. The other methods of Rasterizer close their
+// shapes automatically.
+func (r *Rasterizer) AddLineSeg(seg geom.LineSeg) {
+	r.line(seg[0], seg[1])
+}
+
+func (r *Rasterizer) addRing(pts []geom.Point) {
+	if len(pts) < 2 {
+		return
+	}
+	for i := 1; i < len(pts); i++ {
+		r.line(pts[i-1], pts[i])
+	}
+	r.line(pts[len(pts)-1], pts[0])
+}
+
+// line accumulates the signed area to the right of a line segment within
+// each pixel it passes through.
+func (r *Rasterizer) line(p0, p1 geom.Point) {
+	off := geom.Point{X: float64(r.bounds.Min.X), Y: float64(r.bounds.Min.Y)}
+	p0, p1 = p0.Sub(off), p1.Sub(off)
+	if p0.Y == p1.Y || math.IsNaN(p0.X+p0.Y+p1.X+p1.Y) {
+		return
+	}
+	dir := 1.0
+	if p0.Y > p1.Y {
+		dir = -1
+		p0, p1 = p1, p0
+	}
+	w, h := float64(r.bounds.Dx()), float64(r.bounds.Dy())
+	if p1.Y <= 0 || p0.Y >= h {
+		return
+	}
+
+	dxdy := (p1.X - p0.X) / (p1.Y - p0.Y)
+	x := p0.X
+	if p0.Y < 0 {
+		x -= p0.Y * dxdy
+	}
+	yStart := int(math.Max(math.Floor(p0.Y), 0))
+	yEnd := int(math.Min(math.Ceil(p1.Y), h))
+	for y := yStart; y < yEnd; y++ {
+		row := r.acc[y*r.stride : (y+1)*r.stride]
+		fy := float64(y)
+		dy := math.Min(fy+1, p1.Y) - math.Max(fy, p0.Y)
+		xNext := x + dxdy*dy
+		d := float32(dy * dir)
+
+		// Parts of the edge beyond the left or right of the bounds affect
+		// the pixels within the bounds as if they were on the boundary.
+		x0 := math.Min(math.Max(x, 0), w)
+		x1 := math.Min(math.Max(xNext, 0), w)
+		if x0 > x1 {
+			x0, x1 = x1, x0
+		}
+		x0Floor := math.Floor(x0)
+		x0i := int(x0Floor)
+		x1Ceil := math.Ceil(x1)
+		x1i := int(x1Ceil)
+
+		if x1i <= x0i+1 {
+			// The edge is within a single pixel in this row.
+			xm := float32(0.5*(x0+x1) - x0Floor)
+			row[x0i] += d - d*xm
+			row[x0i+1] += d * xm
+		} else {
+			s := 1 / (x1 - x0)
+			x0f := x0 - x0Floor
+			a0 := 0.5 * s * (1 - x0f) * (1 - x0f)
+			x1f := x1 - x1Ceil + 1
+			am := 0.5 * s * x1f * x1f
+			row[x0i] += d * float32(a0)
+			if x1i == x0i+2 {
+				row[x0i+1] += d * float32(1-a0-am)
+			} else {
+				a1 := s * (1.5 - x0f)
+				row[x0i+1] += d * float32(a1-a0)
+				for xi := x0i + 2; xi < x1i-1; xi++ {
+					row[xi] += d * float32(s)
+				}
+				a2 := a1 + float64(x1i-x0i-3)*s
+				row[x1i-1] += d * float32(1-a2-am)
+			}
+			row[x1i] += d * float32(am)
+		}
+		x = xNext
+	}
+}
+
+// Mask returns the coverage of the shapes added so far as a new image with
+// the same bounds as the rasterizer, using the given fill rule.
+func (r *Rasterizer) Mask(rule geom.FillRule) *image.Alpha {
+	ret := image.NewAlpha(r.bounds)
+	r.Rasterize(ret, rule)
+	return ret
+}
+
+// Rasterize writes the coverage of the shapes added so far into the part of
+// the given image that overlaps the rasterizer's bounds, using the given
+// fill rule. Existing pixels in that part of the image are overwritten.
+func (r *Rasterizer) Rasterize(dst *image.Alpha, rule geom.FillRule) {
+	b := r.bounds.Intersect(dst.Rect)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := r.acc[(y-r.bounds.Min.Y)*r.stride:]
+		var sum float32
+		for x := r.bounds.Min.X; x < b.Max.X; x++ {
+			sum += row[x-r.bounds.Min.X]
+			if x < b.Min.X {
+				continue
+			}
+			dst.Pix[dst.PixOffset(x, y)] = uint8(coverage(sum, rule)*255 + 0.5)
+		}
+	}
+}
+
+// Draw composites the given source image onto the given destination image,
+// using the coverage of the shapes added so far as a mask. The point sp in
+// the source is aligned with the minimum point of the rasterizer's bounds.
+func (r *Rasterizer) Draw(dst draw.Image, src image.Image, sp image.Point, rule geom.FillRule) {
+	draw.DrawMask(dst, r.bounds, src, sp, r.Mask(rule), r.bounds.Min, draw.Over)
+}
+
+// coverage converts an accumulated signed coverage value into a coverage
+// between zero and one under the given fill rule.
+//
+// A pixel that is only partly covered has a value between two whole
+// winding numbers, and so its coverage is interpolated between theirs.
+func coverage(v float32, rule geom.FillRule) float32 {
+	if v < 0 {
+		v = -v
+	}
+	n := float32(math.Floor(float64(v)))
+	f := v - n
+	var ret float32
+	if rule.Filled(int(n)) {
+		ret += 1 - f
+	}
+	if rule.Filled(int(n) + 1) {
+		ret += f
+	}
+	return ret
+}
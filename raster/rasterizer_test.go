@@ -0,0 +1,141 @@
+package raster
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+func TestRasterizerPoly(t *testing.T) {
+	tests := []struct {
+		Poly geom.Poly
+		Want [4][4]uint8
+	}{
+		{
+			// Aligned exactly to the pixel grid
+			geom.Poly{{1, 1}, {3, 1}, {3, 3}, {1, 3}},
+			[4][4]uint8{
+				{0, 0, 0, 0},
+				{0, 255, 255, 0},
+				{0, 255, 255, 0},
+				{0, 0, 0, 0},
+			},
+		},
+		{
+			// Offset by half a pixel, with the opposite winding
+			geom.Poly{{0.5, 0.5}, {0.5, 2.5}, {2.5, 2.5}, {2.5, 0.5}},
+			[4][4]uint8{
+				{64, 128, 64, 0},
+				{128, 255, 128, 0},
+				{64, 128, 64, 0},
+				{0, 0, 0, 0},
+			},
+		},
+		{
+			// Diagonal edge through the middle of each pixel it crosses
+			geom.Poly{{0, 0}, {4, 0}, {0, 4}},
+			[4][4]uint8{
+				{255, 255, 255, 128},
+				{255, 255, 128, 0},
+				{255, 128, 0, 0},
+				{128, 0, 0, 0},
+			},
+		},
+		{
+			// Extends beyond the bounds on all sides
+			geom.Poly{{-10, -10}, {10, -10}, {10, 2}, {-10, 2}},
+			[4][4]uint8{
+				{255, 255, 255, 255},
+				{255, 255, 255, 255},
+				{0, 0, 0, 0},
+				{0, 0, 0, 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Poly), func(t *testing.T) {
+			r := NewRasterizer(image.Rect(0, 0, 4, 4))
+			r.AddPoly(test.Poly)
+			got := r.Mask(geom.NonZero)
+			for y, row := range test.Want {
+				for x, want := range row {
+					if got := got.AlphaAt(x, y).A; got != want {
+						t.Errorf("wrong alpha at (%d, %d): got %d, want %d", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRasterizerFillRule(t *testing.T) {
+	// Two concentric squares with the same winding direction.
+	r := NewRasterizer(image.Rect(10, 10, 16, 16))
+	r.AddPoly(geom.Poly{{10, 10}, {16, 10}, {16, 16}, {10, 16}})
+	r.AddPoly(geom.Poly{{12, 12}, {14, 12}, {14, 14}, {12, 14}})
+
+	tests := []struct {
+		Rule         geom.FillRule
+		Inner, Outer uint8
+	}{
+		{geom.NonZero, 255, 255},
+		{geom.EvenOdd, 0, 255},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Rule), func(t *testing.T) {
+			mask := r.Mask(test.Rule)
+			if got := mask.AlphaAt(13, 13).A; got != test.Inner {
+				t.Errorf("wrong inner alpha %d; want %d", got, test.Inner)
+			}
+			if got := mask.AlphaAt(11, 11).A; got != test.Outer {
+				t.Errorf("wrong outer alpha %d; want %d", got, test.Outer)
+			}
+		})
+	}
+}
+
+func TestRasterizerPath(t *testing.T) {
+	// A circle of radius 8, whose total coverage should match its area.
+	path := geom.Path(nil).
+		MoveTo(geom.Point{18, 10}).
+		ArcTo(geom.Point{8, 8}, 0, false, true, geom.Point{2, 10}).
+		ArcTo(geom.Point{8, 8}, 0, false, true, geom.Point{18, 10}).
+		Close()
+
+	r := NewRasterizer(image.Rect(0, 0, 20, 20))
+	r.AddPath(path, 0.01)
+	mask := r.Mask(geom.NonZero)
+
+	var total float64
+	for _, a := range mask.Pix {
+		total += float64(a) / 255
+	}
+	if want := math.Pi * 64; math.Abs(total-want) > 0.5 {
+		t.Errorf("wrong total coverage %g; want %g", total, want)
+	}
+	if got := mask.AlphaAt(10, 10).A; got != 255 {
+		t.Errorf("wrong alpha at center %d; want 255", got)
+	}
+	if got := mask.AlphaAt(0, 0).A; got != 0 {
+		t.Errorf("wrong alpha at corner %d; want 0", got)
+	}
+}
+
+func TestRasterizerDraw(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	r := NewRasterizer(dst.Bounds())
+	r.AddPoly(geom.Poly{{0, 0}, {1, 0}, {1, 1}, {0, 1}})
+	r.Draw(dst, image.NewUniform(color.RGBA{255, 0, 0, 255}), image.Point{}, geom.NonZero)
+
+	if got, want := dst.RGBAAt(0, 0), (color.RGBA{255, 0, 0, 255}); got != want {
+		t.Errorf("wrong filled pixel %#v; want %#v", got, want)
+	}
+	if got, want := dst.RGBAAt(1, 0), (color.RGBA{}); got != want {
+		t.Errorf("wrong unfilled pixel %#v; want %#v", got, want)
+	}
+}
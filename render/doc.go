@@ -0,0 +1,7 @@
+// Package render draws paths from package svgpath onto images, filling and
+// stroking them with solid colors.
+//
+// It is intended for simple uses such as generating previews and
+// thumbnails, and implements only a small subset of the painting model of
+// SVG.
+package render
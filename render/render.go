@@ -0,0 +1,126 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+	"github.com/apparentlymart/go-geometry/raster"
+	"github.com/apparentlymart/go-geometry/svgpath"
+)
+
+// tolerance is the greatest distance in pixels by which the drawn shapes
+// may differ from the true curves.
+const tolerance = 0.1
+
+// Style describes how to paint a path.
+//
+// An Opacity of zero, as in the zero value of Style, means fully opaque
+// rather than invisible. To draw nothing, leave both Fill and Stroke nil.
+type Style struct {
+	// Fill is the color used to fill the interior of the path, or nil if
+	// the path shouldn't be filled. Open sub-paths are filled as if they
+	// were closed.
+	Fill     color.Color
+	FillRule geom.FillRule
+
+	// Stroke is the color used to draw the outline of the path, or nil if
+	// the path shouldn't be stroked.
+	Stroke      color.Color
+	StrokeStyle geom.StrokeStyle
+
+	// Opacity is the opacity of the path as a whole, from 0 to 1, which is
+	// applied after the stroke has been drawn over the fill. So that the
+	// zero value of Style is useful, an opacity of zero is treated as 1.
+	Opacity float64
+}
+
+// Draw paints the given path onto the given image using the given style.
+//
+// The path's coordinates are first transformed by the given transformation,
+// and the result is interpreted as image coordinates. The stroke is
+// transformed along with the path, so that for example a non-uniform scale
+// produces a stroke of varying width. Pass geom.IdentityAffine to draw the
+// path in its own coordinates; the zero geom.Affine collapses every point to
+// the origin, and so draws nothing.
+func Draw(dst draw.Image, p svgpath.Path, style Style, transform geom.Affine) {
+	DrawGeomPath(dst, p.GeomPath(), style, transform)
+}
+
+// DrawGeomPath is like Draw, but takes a path from package geom.
+func DrawGeomPath(dst draw.Image, p geom.Path, style Style, transform geom.Affine) {
+	b := dst.Bounds()
+	if style.Opacity > 0 && style.Opacity < 1 {
+		// Drawing the fill and stroke separately with reduced opacity would
+		// show the fill through the stroke, so we draw them both fully
+		// opaque and then draw the result with reduced opacity.
+		layer := image.NewRGBA(b)
+		opaque := style
+		opaque.Opacity = 1
+		DrawGeomPath(layer, p, opaque, transform)
+		mask := image.NewUniform(color.Alpha{A: uint8(style.Opacity*255 + 0.5)})
+		draw.DrawMask(dst, b, layer, b.Min, mask, image.Point{}, draw.Over)
+		return
+	}
+
+	r := raster.NewRasterizer(b)
+	if style.Fill != nil {
+		r.AddPath(p.Transform(transform), tolerance)
+		r.Draw(dst, image.NewUniform(style.Fill), image.Point{}, style.FillRule)
+	}
+	if style.Stroke != nil {
+		r.Reset()
+		addStroke(r, p, style.StrokeStyle, transform)
+		r.Draw(dst, image.NewUniform(style.Stroke), image.Point{}, geom.NonZero)
+	}
+}
+
+// addStroke adds the outline of the stroked path to the given rasterizer.
+//
+// The stroke is constructed before transforming, since that is where its
+// width is defined, and so the tolerance is reduced to account for the
+// scaling of the transformation.
+func addStroke(r *raster.Rasterizer, p geom.Path, st geom.StrokeStyle, transform geom.Affine) {
+	scale := transform.MaxScale()
+	if scale == 0 || math.IsInf(scale, 0) || math.IsNaN(scale) {
+		return
+	}
+	tol := tolerance / scale
+	for _, c := range p {
+		if len(c.Segments) == 0 {
+			continue
+		}
+		for _, poly := range st.Polys(c.Flatten(tol), c.Closed, tol) {
+			for i, pt := range poly {
+				poly[i] = transform.Apply(pt)
+			}
+			r.AddPoly(poly)
+		}
+	}
+}
+
+// FitAffine returns a transformation that scales and translates the given
+// rectangle so that it fits within the given image bounds, as large as
+// possible while preserving its aspect ratio and centered in the direction
+// where it doesn't fill the bounds.
+//
+// This is similar to the transformation implied by an SVG viewBox with the
+// default preserveAspectRatio, and so the Y axis still increases downward.
+func FitAffine(src geom.Rect, dst image.Rectangle) geom.Affine {
+	src = src.Normalize()
+	size := src[1].Sub(src[0])
+	dx, dy := float64(dst.Dx()), float64(dst.Dy())
+	scale := math.Min(dx/size.X, dy/size.Y)
+	if math.IsInf(scale, 0) || math.IsNaN(scale) {
+		scale = 1
+	}
+	offset := geom.Point{
+		X: float64(dst.Min.X) + (dx-size.X*scale)/2,
+		Y: float64(dst.Min.Y) + (dy-size.Y*scale)/2,
+	}
+	return geom.TranslateAffine(src[0].Scale(-1)).
+		Then(geom.ScaleAffine(geom.Point{X: scale, Y: scale})).
+		Then(geom.TranslateAffine(offset))
+}
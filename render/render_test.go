@@ -0,0 +1,160 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+	"github.com/apparentlymart/go-geometry/svgpath"
+)
+
+func TestDraw(t *testing.T) {
+	square, err := svgpath.Parse("M 2 2 H 8 V 8 H 2 Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	red := color.RGBA{255, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+
+	tests := []struct {
+		Style     Style
+		Transform geom.Affine
+		Want      map[image.Point]color.RGBA
+	}{
+		{
+			Style{Fill: red},
+			geom.IdentityAffine,
+			map[image.Point]color.RGBA{
+				{5, 5}: red,
+				{2, 2}: red,
+				{1, 1}: {},
+				{8, 8}: {},
+			},
+		},
+		{
+			Style{
+				Fill:        red,
+				Stroke:      blue,
+				StrokeStyle: geom.StrokeStyle{Width: 2},
+			},
+			geom.IdentityAffine,
+			map[image.Point]color.RGBA{
+				{5, 5}: red,
+				{1, 5}: blue,
+				{2, 5}: blue,
+				{3, 5}: red,
+				{1, 1}: blue,
+				{0, 0}: {},
+			},
+		},
+		{
+			// With reduced opacity, the fill doesn't show through the
+			// stroke.
+			Style{
+				Fill:        red,
+				Stroke:      blue,
+				StrokeStyle: geom.StrokeStyle{Width: 2},
+				Opacity:     0.5,
+			},
+			geom.IdentityAffine,
+			map[image.Point]color.RGBA{
+				{5, 5}: {128, 0, 0, 128},
+				{2, 5}: {0, 0, 128, 128},
+				{0, 0}: {},
+			},
+		},
+		{
+			// The zero transformation isn't the identity, and collapses the
+			// path to a point.
+			Style{
+				Fill:        red,
+				Stroke:      blue,
+				StrokeStyle: geom.StrokeStyle{Width: 2},
+			},
+			geom.Affine{},
+			map[image.Point]color.RGBA{
+				{0, 0}: {},
+				{5, 5}: {},
+			},
+		},
+		{
+			Style{Fill: red},
+			geom.ScaleAffine(geom.Point{X: 0.5, Y: 0.5}),
+			map[image.Point]color.RGBA{
+				{1, 1}: red,
+				{3, 3}: red,
+				{4, 4}: {},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Style), func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+			Draw(img, square, test.Style, test.Transform)
+			for pt, want := range test.Want {
+				if got := img.RGBAAt(pt.X, pt.Y); got != want {
+					t.Errorf("wrong color at %s: got %#v, want %#v", pt, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawOpacity(t *testing.T) {
+	square, err := svgpath.Parse("M 2 2 H 8 V 8 H 2 Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	red := color.RGBA{255, 0, 0, 255}
+
+	// An opacity of zero is the default, and draws the same as 1.
+	for _, opacity := range []float64{0, 1} {
+		t.Run(fmt.Sprintf("%#v", opacity), func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+			Draw(img, square, Style{Fill: red, Opacity: opacity}, geom.IdentityAffine)
+			if got := img.RGBAAt(5, 5); got != red {
+				t.Errorf("wrong color: got %#v, want %#v", got, red)
+			}
+		})
+	}
+}
+
+func TestFitAffine(t *testing.T) {
+	tests := []struct {
+		Src     geom.Rect
+		Dst     image.Rectangle
+		Corners [2]geom.Point
+	}{
+		{
+			geom.Rect{{0, 0}, {24, 24}},
+			image.Rect(0, 0, 48, 48),
+			[2]geom.Point{{0, 0}, {48, 48}},
+		},
+		{
+			// Wider than the destination, so centered vertically
+			geom.Rect{{10, 10}, {30, 20}},
+			image.Rect(0, 0, 10, 10),
+			[2]geom.Point{{0, 2.5}, {10, 7.5}},
+		},
+		{
+			// Taller than the destination, so centered horizontally
+			geom.Rect{{0, 0}, {10, 20}},
+			image.Rect(10, 0, 30, 10),
+			[2]geom.Point{{17.5, 0}, {22.5, 10}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Src), func(t *testing.T) {
+			a := FitAffine(test.Src, test.Dst)
+			for i, want := range test.Corners {
+				if got := a.Apply(test.Src[i]); !got.ApproxEqual(want, 1e-12) {
+					t.Errorf("wrong corner %d\ngot:  %#v\nwant: %#v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package rtree implements an R-tree, which is a spatial index of values
+// keyed by rectangles from package geom.
+package rtree
@@ -0,0 +1,28 @@
+package rtree
+
+import (
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// margin returns half the perimeter of the given rectangle.
+func margin(r geom.Rect) float64 {
+	s := r.Size()
+	return math.Abs(s.X) + math.Abs(s.Y)
+}
+
+// overlapArea returns the area of the intersection of a and b, or zero if
+// they don't overlap.
+func overlapArea(a, b geom.Rect) float64 {
+	r, _ := a.Intersect(b)
+	return r.Area()
+}
+
+// distSq returns the squared distance from p to the nearest point in the
+// normalized rectangle r, which is zero if p is inside r.
+func distSq(r geom.Rect, p geom.Point) float64 {
+	dx := math.Max(0, math.Max(r[0].X-p.X, p.X-r[1].X))
+	dy := math.Max(0, math.Max(r[0].Y-p.Y, p.Y-r[1].Y))
+	return dx*dx + dy*dy
+}
@@ -0,0 +1,427 @@
+package rtree
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+const (
+	maxEntries = 16
+	minEntries = 6
+)
+
+// Tree is an R-tree, which stores values of type V each associated with a
+// rectangle and can efficiently find the values whose rectangles are near
+// a given point or rectangle.
+//
+// The zero value of Tree is an empty tree ready to use. A Tree must not be
+// copied after first use.
+//
+// The methods of Tree are safe to call concurrently. Any number of searches
+// may run at the same time, but modifications wait for all searches to
+// complete and then block other operations until they are done.
+type Tree[V any] struct {
+	mu   sync.RWMutex
+	root *node[V]
+	size int
+}
+
+// Item is a value stored in a Tree, along with the rectangle it is keyed by.
+type Item[V any] struct {
+	Bounds geom.Rect
+	Value  V
+}
+
+type node[V any] struct {
+	// height is one for leaf nodes, and one more than the height of the
+	// children for other nodes.
+	height  int
+	entries []entry[V]
+}
+
+// entry is either an item in a leaf node, or a child of another node. The
+// bounds of a child are the smallest rectangle containing all of its
+// entries.
+type entry[V any] struct {
+	bounds geom.Rect
+	child  *node[V]
+	value  V
+}
+
+// Load creates a tree containing the given items, using the
+// Sort-Tile-Recursive algorithm to pack them tightly into nodes.
+//
+// Loading all of the items at once is considerably faster than inserting
+// them one at a time, and usually produces a tree that is faster to search.
+func Load[V any](items []Item[V]) *Tree[V] {
+	t := &Tree[V]{}
+	if len(items) == 0 {
+		return t
+	}
+	entries := make([]entry[V], len(items))
+	for i, item := range items {
+		entries[i] = entry[V]{bounds: item.Bounds.Normalize(), value: item.Value}
+	}
+	height := 1
+	for len(entries) > maxEntries {
+		entries = packLevel(entries, height)
+		height++
+	}
+	t.root = &node[V]{height: height, entries: entries}
+	t.size = len(items)
+	return t
+}
+
+// packLevel groups the given entries into nodes of the given height using
+// the Sort-Tile-Recursive algorithm, returning entries for the new nodes.
+func packLevel[V any](entries []entry[V], height int) []entry[V] {
+	nodes := (len(entries) + maxEntries - 1) / maxEntries
+	slices := int(math.Ceil(math.Sqrt(float64(nodes))))
+	perSlice := slices * maxEntries
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].bounds.Center().X < entries[j].bounds.Center().X
+	})
+	ret := make([]entry[V], 0, nodes)
+	for s := 0; s < len(entries); s += perSlice {
+		slice := entries[s:min(s+perSlice, len(entries))]
+		sort.Slice(slice, func(i, j int) bool {
+			return slice[i].bounds.Center().Y < slice[j].bounds.Center().Y
+		})
+		for k := 0; k < len(slice); k += maxEntries {
+			n := &node[V]{
+				height:  height,
+				entries: append([]entry[V](nil), slice[k:min(k+maxEntries, len(slice))]...),
+			}
+			ret = append(ret, entry[V]{bounds: n.bounds(), child: n})
+		}
+	}
+	return ret
+}
+
+// Len returns the number of items in the tree.
+func (t *Tree[V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// Insert adds a value to the tree, keyed by the given rectangle.
+//
+// The same value may be inserted more than once, with the same or different
+// rectangles, in which case each is a separate item.
+func (t *Tree[V]) Insert(bounds geom.Rect, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.insert(entry[V]{bounds: bounds.Normalize(), value: value})
+	t.size++
+}
+
+func (t *Tree[V]) insert(e entry[V]) {
+	if t.root == nil {
+		t.root = &node[V]{height: 1}
+	}
+
+	// Find the leaf needing the least enlargement to include the new entry,
+	// remembering the path to it.
+	path := []*node[V]{t.root}
+	for n := t.root; n.height > 1; {
+		best := -1
+		var bestGrowth, bestArea float64
+		for i, c := range n.entries {
+			a := c.bounds.Area()
+			growth := c.bounds.Union(e.bounds).Area() - a
+			if best < 0 || growth < bestGrowth || (growth == bestGrowth && a < bestArea) {
+				best, bestGrowth, bestArea = i, growth, a
+			}
+		}
+		n = n.entries[best].child
+		path = append(path, n)
+	}
+
+	leaf := path[len(path)-1]
+	leaf.entries = append(leaf.entries, e)
+	updateBounds(path)
+
+	// Split any nodes that are now too full, working back towards the root.
+	for level := len(path) - 1; level >= 0 && len(path[level].entries) > maxEntries; level-- {
+		n := path[level]
+		sibling := n.split()
+		se := entry[V]{bounds: sibling.bounds(), child: sibling}
+		if level == 0 {
+			t.root = &node[V]{
+				height:  n.height + 1,
+				entries: []entry[V]{{bounds: n.bounds(), child: n}, se},
+			}
+			break
+		}
+		parent := path[level-1]
+		parent.updateChild(n)
+		parent.entries = append(parent.entries, se)
+	}
+}
+
+// updateBounds recalculates the bounds of each node on the given path from
+// the root, in the entries of their parents.
+func updateBounds[V any](path []*node[V]) {
+	for level := len(path) - 1; level > 0; level-- {
+		path[level-1].updateChild(path[level])
+	}
+}
+
+// updateChild recalculates the bounds of the given child of the receiver.
+func (n *node[V]) updateChild(child *node[V]) {
+	for i := range n.entries {
+		if n.entries[i].child == child {
+			n.entries[i].bounds = child.bounds()
+			return
+		}
+	}
+}
+
+// Delete removes an item from the tree, returning true if an item was
+// removed. The item must have exactly the given rectangle, after
+// normalization, and the given match function must return true for its
+// value. If there are several such items then only one is removed.
+func (t *Tree[V]) Delete(bounds geom.Rect, match func(V) bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.root == nil {
+		return false
+	}
+	bounds = bounds.Normalize()
+
+	path, idx := t.root.find(bounds, match, nil)
+	if path == nil {
+		return false
+	}
+	leaf := path[len(path)-1]
+	leaf.entries = append(leaf.entries[:idx], leaf.entries[idx+1:]...)
+	t.size--
+
+	// Any node left with too few entries is removed, and the items beneath
+	// it are inserted again afterwards.
+	var orphans []entry[V]
+	for level := len(path) - 1; level > 0; level-- {
+		n, parent := path[level], path[level-1]
+		for i := range parent.entries {
+			if parent.entries[i].child != n {
+				continue
+			}
+			if len(n.entries) < minEntries {
+				orphans = n.appendItems(orphans)
+				parent.entries = append(parent.entries[:i], parent.entries[i+1:]...)
+			} else {
+				parent.entries[i].bounds = n.bounds()
+			}
+			break
+		}
+	}
+	for t.root.height > 1 && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	if len(t.root.entries) == 0 {
+		t.root = nil
+	}
+	for _, e := range orphans {
+		t.insert(e)
+	}
+	return true
+}
+
+// find returns the path from the receiver to the leaf containing an item
+// with the given bounds whose value matches, along with the item's index
+// in the leaf, or nil if there is no such item.
+func (n *node[V]) find(bounds geom.Rect, match func(V) bool, path []*node[V]) ([]*node[V], int) {
+	path = append(path, n)
+	for i, e := range n.entries {
+		if !e.bounds.ContainsRect(bounds) {
+			continue
+		}
+		if n.height == 1 {
+			if e.bounds == bounds && match(e.value) {
+				return path, i
+			}
+			continue
+		}
+		if found, idx := e.child.find(bounds, match, path); found != nil {
+			return found, idx
+		}
+	}
+	return nil, 0
+}
+
+// appendItems appends the entries of all of the items beneath the receiver
+// to dst.
+func (n *node[V]) appendItems(dst []entry[V]) []entry[V] {
+	if n.height == 1 {
+		return append(dst, n.entries...)
+	}
+	for _, e := range n.entries {
+		dst = e.child.appendItems(dst)
+	}
+	return dst
+}
+
+func (n *node[V]) bounds() geom.Rect {
+	r := n.entries[0].bounds
+	for _, e := range n.entries[1:] {
+		r = r.Union(e.bounds)
+	}
+	return r
+}
+
+// split moves some of the entries of the receiver into a new node and
+// returns it. The entries are divided along whichever axis gives the
+// smallest total margin, at the point where the overlap between the two
+// nodes is smallest.
+func (n *node[V]) split() *node[V] {
+	byX := func(i, j int) bool {
+		a, b := n.entries[i].bounds, n.entries[j].bounds
+		return a[0].X < b[0].X || (a[0].X == b[0].X && a[1].X < b[1].X)
+	}
+	byY := func(i, j int) bool {
+		a, b := n.entries[i].bounds, n.entries[j].bounds
+		return a[0].Y < b[0].Y || (a[0].Y == b[0].Y && a[1].Y < b[1].Y)
+	}
+	sort.Slice(n.entries, byX)
+	marginX := n.splitMargin()
+	sort.Slice(n.entries, byY)
+	if marginY := n.splitMargin(); marginX < marginY {
+		sort.Slice(n.entries, byX)
+	}
+
+	best := -1
+	var bestOverlap, bestArea float64
+	for k := minEntries; k <= len(n.entries)-minEntries; k++ {
+		a, b := boundsOf(n.entries[:k]), boundsOf(n.entries[k:])
+		overlap, ar := overlapArea(a, b), a.Area()+b.Area()
+		if best < 0 || overlap < bestOverlap || (overlap == bestOverlap && ar < bestArea) {
+			best, bestOverlap, bestArea = k, overlap, ar
+		}
+	}
+
+	sibling := &node[V]{
+		height:  n.height,
+		entries: append([]entry[V](nil), n.entries[best:]...),
+	}
+	n.entries = append([]entry[V](nil), n.entries[:best]...)
+	return sibling
+}
+
+// splitMargin returns the total margin of all of the possible ways to split
+// the receiver's entries into two groups in their current order.
+func (n *node[V]) splitMargin() float64 {
+	var m float64
+	for k := minEntries; k <= len(n.entries)-minEntries; k++ {
+		m += margin(boundsOf(n.entries[:k])) + margin(boundsOf(n.entries[k:]))
+	}
+	return m
+}
+
+func boundsOf[V any](entries []entry[V]) geom.Rect {
+	r := entries[0].bounds
+	for _, e := range entries[1:] {
+		r = r.Union(e.bounds)
+	}
+	return r
+}
+
+// Search calls fn for each item whose rectangle intersects the given
+// rectangle, including items that only touch its edges. The search stops
+// early if fn returns false.
+//
+// The items are visited in no particular order. fn must not modify the
+// tree.
+func (t *Tree[V]) Search(bounds geom.Rect, fn func(Item[V]) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.root == nil {
+		return
+	}
+	t.root.search(bounds.Normalize(), fn)
+}
+
+// SearchPoint calls fn for each item whose rectangle contains the given
+// point, including on its edges. The search stops early if fn returns
+// false.
+//
+// The items are visited in no particular order. fn must not modify the
+// tree.
+func (t *Tree[V]) SearchPoint(p geom.Point, fn func(Item[V]) bool) {
+	t.Search(geom.Rect{p, p}, fn)
+}
+
+func (n *node[V]) search(bounds geom.Rect, fn func(Item[V]) bool) bool {
+	for _, e := range n.entries {
+		if !e.bounds.Overlaps(bounds) {
+			continue
+		}
+		if n.height == 1 {
+			if !fn(Item[V]{e.bounds, e.value}) {
+				return false
+			}
+		} else if !e.child.search(bounds, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Nearest returns up to k items whose rectangles are closest to the given
+// point, in order of increasing distance. The distance to a rectangle is
+// zero if the point is inside it. Items at equal distances are returned in
+// no particular order.
+func (t *Tree[V]) Nearest(p geom.Point, k int) []Item[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.root == nil || k <= 0 {
+		return nil
+	}
+
+	// We visit nodes and items in order of their distance from p, so any
+	// item we reach is at least as close as everything still queued.
+	var ret []Item[V]
+	q := nearestQueue[V]{{node: t.root}}
+	for q.Len() > 0 && len(ret) < k {
+		c := heap.Pop(&q).(nearestCandidate[V])
+		if c.node == nil {
+			ret = append(ret, c.item)
+			continue
+		}
+		for _, e := range c.node.entries {
+			nc := nearestCandidate[V]{dist: distSq(e.bounds, p), node: e.child}
+			if e.child == nil {
+				nc.item = Item[V]{e.bounds, e.value}
+			}
+			heap.Push(&q, nc)
+		}
+	}
+	return ret
+}
+
+// nearestCandidate is either a node or an item, if node is nil, along with
+// its squared distance from the point being searched for.
+type nearestCandidate[V any] struct {
+	dist float64
+	node *node[V]
+	item Item[V]
+}
+
+// nearestQueue is a min-heap of candidates ordered by distance.
+type nearestQueue[V any] []nearestCandidate[V]
+
+func (q nearestQueue[V]) Len() int            { return len(q) }
+func (q nearestQueue[V]) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q nearestQueue[V]) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nearestQueue[V]) Push(x interface{}) { *q = append(*q, x.(nearestCandidate[V])) }
+func (q *nearestQueue[V]) Pop() interface{} {
+	old := *q
+	ret := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ret
+}
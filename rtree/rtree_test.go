@@ -0,0 +1,141 @@
+package rtree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+	"github.com/go-test/deep"
+)
+
+func TestTreeSearch(t *testing.T) {
+	tree := &Tree[string]{}
+	tree.Insert(geom.Rect{{0, 0}, {2, 2}}, "a")
+	tree.Insert(geom.Rect{{5, 5}, {3, 3}}, "b") // not normalized
+	tree.Insert(geom.Rect{{10, 0}, {12, 1}}, "c")
+
+	tests := []struct {
+		Bounds geom.Rect
+		Want   []string
+	}{
+		{geom.Rect{{-1, -1}, {1, 1}}, []string{"a"}},
+		{geom.Rect{{2, 2}, {3, 3}}, []string{"a", "b"}}, // touching
+		{geom.Rect{{0, 0}, {20, 20}}, []string{"a", "b", "c"}},
+		{geom.Rect{{6, 6}, {7, 7}}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Bounds), func(t *testing.T) {
+			var got []string
+			tree.Search(test.Bounds, func(item Item[string]) bool {
+				got = append(got, item.Value)
+				return true
+			})
+			sort.Strings(got)
+
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+
+	var got []string
+	tree.SearchPoint(geom.Point{4, 4}, func(item Item[string]) bool {
+		got = append(got, item.Value)
+		return true
+	})
+	for _, problem := range deep.Equal(got, []string{"b"}) {
+		t.Error(problem)
+	}
+
+	nearest := tree.Nearest(geom.Point{9, 0}, 2)
+	if len(nearest) != 2 || nearest[0].Value != "c" || nearest[1].Value != "b" {
+		t.Errorf("wrong nearest items %#v; want c and then b", nearest)
+	}
+}
+
+// TestTreeRandom compares the results of a tree built both incrementally and
+// by bulk loading with those of a linear scan.
+func TestTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randRect := func(size float64) geom.Rect {
+		p := geom.Point{r.Float64() * 1000, r.Float64() * 1000}
+		return geom.Rect{p, p.Add(geom.Point{r.Float64() * size, r.Float64() * size})}
+	}
+
+	items := make([]Item[int], 2000)
+	for i := range items {
+		items[i] = Item[int]{randRect(20), i}
+	}
+
+	loaded := Load(items)
+	inserted := &Tree[int]{}
+	for _, item := range items {
+		inserted.Insert(item.Bounds, item.Value)
+	}
+
+	// Delete every third item from both trees.
+	live := make(map[int]bool)
+	for _, item := range items {
+		live[item.Value] = true
+	}
+	for i := 0; i < len(items); i += 3 {
+		item := items[i]
+		match := func(v int) bool { return v == item.Value }
+		for _, tree := range []*Tree[int]{loaded, inserted} {
+			if !tree.Delete(item.Bounds, match) {
+				t.Fatalf("failed to delete item %d", item.Value)
+			}
+			if tree.Delete(item.Bounds, match) {
+				t.Fatalf("deleted item %d twice", item.Value)
+			}
+		}
+		delete(live, item.Value)
+	}
+
+	for name, tree := range map[string]*Tree[int]{"loaded": loaded, "inserted": inserted} {
+		t.Run(name, func(t *testing.T) {
+			if got, want := tree.Len(), len(live); got != want {
+				t.Errorf("wrong length %d; want %d", got, want)
+			}
+
+			for q := 0; q < 100; q++ {
+				query := randRect(100)
+				var got, want []int
+				tree.Search(query, func(item Item[int]) bool {
+					got = append(got, item.Value)
+					return true
+				})
+				for _, item := range items {
+					if live[item.Value] && item.Bounds.Overlaps(query) {
+						want = append(want, item.Value)
+					}
+				}
+				sort.Ints(got)
+				for _, problem := range deep.Equal(got, want) {
+					t.Errorf("search %#v: %s", query, problem)
+				}
+
+				p := query[0]
+				nearest := tree.Nearest(p, 5)
+				var dists []float64
+				for _, item := range items {
+					if live[item.Value] {
+						dists = append(dists, distSq(item.Bounds, p))
+					}
+				}
+				sort.Float64s(dists)
+				if len(nearest) != 5 {
+					t.Fatalf("wrong number of nearest items %d; want 5", len(nearest))
+				}
+				for i, item := range nearest {
+					if got := distSq(item.Bounds, p); got != dists[i] {
+						t.Errorf("nearest item %d to %#v has squared distance %f; want %f", i, p, got, dists[i])
+					}
+				}
+			}
+		})
+	}
+}
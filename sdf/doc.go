@@ -0,0 +1,14 @@
+// Package sdf generates signed distance fields from shapes described using
+// package geom, for use in rendering text and icons that remain sharp at
+// any scale.
+//
+// The distances are calculated exactly from the lines and curves of the
+// shapes, rather than approximated from a rasterized image. Paths from
+// package svgpath can be used after converting them with their GeomPath
+// method.
+//
+// Multi-channel distance fields are generated using the approach described
+// by Viktor Chlumský in "Shape Decomposition for Multi-channel Distance
+// Fields", which preserves sharp corners that a single channel would round
+// off.
+package sdf
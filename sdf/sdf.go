@@ -0,0 +1,73 @@
+package sdf
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// SDF returns a single-channel signed distance field for the shape, covering
+// the given bounds. The shape's coordinates are interpreted as image
+// coordinates, and so a path may need to be transformed using its Transform
+// method first.
+//
+// Each pixel encodes the signed distance from its center to the boundary of
+// the shape, where a distance of zero is encoded as the middle value and
+// the given range is the difference in distance between the smallest and
+// largest values. Pixels inside the shape are lighter than the middle
+// value.
+func (s *Shape) SDF(bounds image.Rectangle, distRange float64) *image.Gray {
+	ret := image.NewGray(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			d := s.distance(pixelCenter(x, y), distRange/2)
+			ret.SetGray(x, y, color.Gray{Y: encode(d, distRange)})
+		}
+	}
+	return ret
+}
+
+// MSDF returns a multi-channel signed distance field for the shape,
+// covering the given bounds. The distances are encoded in the red, green
+// and blue channels as described for SDF, and the alpha channel is always
+// opaque.
+//
+// The shape can be reconstructed with sharp corners by taking the median of
+// the three channels.
+func (s *Shape) MSDF(bounds image.Rectangle, distRange float64) *image.RGBA {
+	ret := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			d := s.MultiDistance(pixelCenter(x, y))
+			ret.SetRGBA(x, y, color.RGBA{
+				R: encode(d[0], distRange),
+				G: encode(d[1], distRange),
+				B: encode(d[2], distRange),
+				A: 255,
+			})
+		}
+	}
+	return ret
+}
+
+// Median returns the median of three values, which reconstructs a signed
+// distance from the channels of a multi-channel distance field.
+func Median(v [3]float64) float64 {
+	s := v[:]
+	sort.Float64s(s)
+	return s[1]
+}
+
+func pixelCenter(x, y int) geom.Point {
+	return geom.Point{X: float64(x) + 0.5, Y: float64(y) + 0.5}
+}
+
+// encode converts a signed distance into a channel value, as described for
+// SDF.
+func encode(d, distRange float64) uint8 {
+	v := d/distRange + 0.5
+	return uint8(math.Round(255 * math.Max(0, math.Min(1, v))))
+}
@@ -0,0 +1,327 @@
+package sdf
+
+import (
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// windingTolerance is the tolerance to which curves are flattened for
+// determining whether points are inside a shape. Points closer than this
+// to the boundary might be given the wrong sign, but their distances are
+// then small enough that it makes little difference.
+const windingTolerance = 1e-3
+
+// cornerCross is the smallest magnitude of the cross product of the unit
+// tangents either side of a point for it to be considered a corner, which
+// is the sine of about 8 degrees.
+const cornerCross = 0.1411
+
+// Shape is a filled shape prepared for distance queries.
+//
+// Shapes are filled using the non-zero winding rule. For multi-channel
+// distances, the inner boundaries of holes must run in the opposite
+// direction to the outer boundaries surrounding them, and contours
+// shouldn't overlap, as is typical for font glyphs.
+type Shape struct {
+	edges []edge
+	rings []geom.Poly
+
+	// orient is 1 if the outer boundaries of the shape are anti-clockwise,
+	// assuming a Y axis that increases upward, or -1 otherwise.
+	orient float64
+}
+
+// edge is a single line or curve of a Shape.
+type edge struct {
+	seg    geom.PathSegment
+	bounds geom.Rect
+
+	// channels is a bitmask of the channels of a multi-channel distance
+	// field that the edge contributes to, with bit 0 for red, bit 1 for
+	// green and bit 2 for blue.
+	channels uint8
+}
+
+const (
+	red     uint8 = 1
+	green   uint8 = 2
+	blue    uint8 = 4
+	yellow        = red | green
+	magenta       = red | blue
+	cyan          = green | blue
+	white         = red | green | blue
+)
+
+// NewShape prepares the given path for distance queries. Each contour of
+// the path is treated as closed, whether or not it is closed in the path.
+func NewShape(p geom.Path) *Shape {
+	// Transforming with the identity converts arcs to cubic curves, which
+	// is what we need to find distances to them.
+	p = p.Transform(geom.IdentityAffine)
+
+	s := &Shape{}
+	var area float64
+	for _, c := range p {
+		var segs []geom.PathSegment
+		for _, seg := range c.Segments {
+			if !degenerate(seg) {
+				segs = append(segs, seg)
+			}
+		}
+		if end := c.End(); end != c.Start {
+			segs = append(segs, geom.LineSeg{end, c.Start})
+		}
+		if len(segs) == 0 {
+			continue
+		}
+
+		ring := geom.Poly(c.Flatten(windingTolerance))
+		s.rings = append(s.rings, ring)
+		area -= float64(ring.Facing()) * ring.Area()
+
+		cs := corners(segs)
+		if len(cs) == 1 && len(segs) < 3 {
+			segs = splitThirds(segs)
+			cs = corners(segs)
+		}
+		for i, ch := range colorEdges(segs, cs) {
+			s.edges = append(s.edges, edge{
+				seg:      segs[i],
+				bounds:   segs[i].Bounds(),
+				channels: ch,
+			})
+		}
+	}
+	s.orient = 1
+	if area < 0 {
+		s.orient = -1
+	}
+	return s
+}
+
+// Distance returns the signed distance from the given point to the boundary
+// of the shape, which is positive inside the shape and negative outside.
+func (s *Shape) Distance(p geom.Point) float64 {
+	return s.distance(p, math.Inf(1))
+}
+
+// distance is like Distance, except that it may return any distance beyond
+// the given limit in place of the true distance if the true distance is
+// also beyond it.
+func (s *Shape) distance(p geom.Point, limit float64) float64 {
+	best := limit
+	for _, e := range s.edges {
+		if e.bounds.Dist(p) >= best {
+			continue
+		}
+		if d := nearest(e.seg, p).Dist; d < best {
+			best = d
+		}
+	}
+	if s.Contains(p) {
+		return best
+	}
+	return -best
+}
+
+// Contains returns true if the given point is inside the shape.
+func (s *Shape) Contains(p geom.Point) bool {
+	w := 0
+	for _, ring := range s.rings {
+		w += ring.Winding(p)
+	}
+	return w != 0
+}
+
+// MultiDistance returns the signed pseudo-distances from the given point to
+// the boundary of the shape for each of the three channels of a
+// multi-channel distance field, in the order red, green, blue.
+//
+// The median of the three values has the same sign as Distance, and its
+// magnitude is close to Distance near the boundary.
+func (s *Shape) MultiDistance(p geom.Point) [3]float64 {
+	var ret [3]float64
+	for i := range ret {
+		ret[i] = s.channelDistance(p, 1<<i)
+	}
+	return ret
+}
+
+// channelDistance returns the signed pseudo-distance to the nearest edge
+// that contributes to the given channel.
+func (s *Shape) channelDistance(p geom.Point, channel uint8) float64 {
+	var found bool
+	var best geom.NearestPoint
+	var bestEdge edge
+	var bestOrtho float64
+	for _, e := range s.edges {
+		if e.channels&channel == 0 {
+			continue
+		}
+		if found && e.bounds.Dist(p) > best.Dist+1e-9 {
+			continue
+		}
+		n := nearest(e.seg, p)
+		ortho := orthogonality(e.seg, n, p)
+		switch {
+		case !found || n.Dist < best.Dist-1e-9:
+		case n.Dist <= best.Dist+1e-9 && ortho > bestOrtho:
+			// Where two edges are equally close, which happens mainly at
+			// the corners between them, we prefer the one that the point
+			// is more directly in front of.
+		default:
+			continue
+		}
+		found, best, bestEdge, bestOrtho = true, n, e, ortho
+	}
+	if !found {
+		return math.Inf(-1)
+	}
+	return s.orient * pseudoDistance(bestEdge.seg, best, p)
+}
+
+// pseudoDistance returns the signed distance from p to the given segment,
+// with the segment extended along its tangents beyond its endpoints. The
+// result is positive if p is to the left of the segment, assuming a Y axis
+// that increases upward.
+func pseudoDistance(seg geom.PathSegment, n geom.NearestPoint, p geom.Point) float64 {
+	tan := tangent(seg, n.T)
+	d := p.Sub(n.Point)
+	dist := n.Dist
+	if tan.Cross(d) < 0 {
+		dist = -dist
+	}
+	if n.T == 0 || n.T == 1 {
+		// Beyond the ends of the segment, the distance to the tangent line
+		// at the end is used if it is closer.
+		if (n.T == 0 && d.Dot(tan) < 0) || (n.T == 1 && d.Dot(tan) > 0) {
+			if pd := tan.Cross(d); math.Abs(pd) <= math.Abs(dist) {
+				dist = pd
+			}
+		}
+	}
+	return dist
+}
+
+// orthogonality returns how directly p is in front of the given nearest
+// point on the segment, from zero if it lies along the tangent to one if it
+// is perpendicular to it.
+func orthogonality(seg geom.PathSegment, n geom.NearestPoint, p geom.Point) float64 {
+	d := p.Sub(n.Point).Normalize()
+	return math.Abs(tangent(seg, n.T).Cross(d))
+}
+
+// corners returns the indices of the segments of a closed contour that
+// begin at a corner, where the direction of the contour changes abruptly.
+func corners(segs []geom.PathSegment) []int {
+	var ret []int
+	for i, seg := range segs {
+		prev := segs[(i+len(segs)-1)%len(segs)]
+		a, b := tangent(prev, 1), tangent(seg, 0)
+		if a.Dot(b) <= 0 || math.Abs(a.Cross(b)) > cornerCross {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+// colorEdges assigns channels to the segments of a closed contour that
+// begin at the given corners, so that the segments either side of each
+// corner share only one channel.
+func colorEdges(segs []geom.PathSegment, corners []int) []uint8 {
+	ret := make([]uint8, len(segs))
+	switch len(corners) {
+	case 0:
+		// A smooth contour has no corners to preserve.
+		for i := range ret {
+			ret[i] = white
+		}
+	case 1:
+		// A contour with a single corner is divided into thirds, with the
+		// middle third contributing to all channels. This requires at
+		// least three segments.
+		for j := range segs {
+			i := (corners[0] + j) % len(segs)
+			ret[i] = []uint8{magenta, white, yellow}[3*j/len(segs)]
+		}
+	default:
+		// Each run of segments between corners alternates between two
+		// colors, with a third color for the last run if there is an odd
+		// number of them, so that it differs from the first run.
+		for k, start := range corners {
+			color := []uint8{cyan, magenta}[k%2]
+			if k == len(corners)-1 && len(corners)%2 == 1 {
+				color = yellow
+			}
+			end := corners[(k+1)%len(corners)]
+			for i := start; ; {
+				ret[i] = color
+				i = (i + 1) % len(segs)
+				if i == end {
+					break
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// splitThirds divides each of the given segments into three equal parts
+// by parameter.
+func splitThirds(segs []geom.PathSegment) []geom.PathSegment {
+	ret := make([]geom.PathSegment, 0, len(segs)*3)
+	for _, seg := range segs {
+		for i := 0; i < 3; i++ {
+			t0, t1 := float64(i)/3, float64(i+1)/3
+			switch seg := seg.(type) {
+			case geom.LineSeg:
+				ret = append(ret, geom.LineSeg{seg[0].Lerp(seg[1], t0), seg[0].Lerp(seg[1], t1)})
+			case geom.QuadraticCurve:
+				ret = append(ret, seg.SubCurve(t0, t1))
+			case geom.CubicCurve:
+				ret = append(ret, seg.SubCurve(t0, t1))
+			}
+		}
+	}
+	return ret
+}
+
+// degenerate returns true if all of the points of the given segment are
+// equal.
+func degenerate(seg geom.PathSegment) bool {
+	switch seg := seg.(type) {
+	case geom.LineSeg:
+		return seg[0] == seg[1]
+	case geom.QuadraticCurve:
+		return seg[0] == seg[1] && seg[1] == seg[2]
+	case geom.CubicCurve:
+		return seg[0] == seg[1] && seg[1] == seg[2] && seg[2] == seg[3]
+	}
+	return false
+}
+
+func nearest(seg geom.PathSegment, p geom.Point) geom.NearestPoint {
+	switch seg := seg.(type) {
+	case geom.LineSeg:
+		return seg.Nearest(p)
+	case geom.QuadraticCurve:
+		return seg.Nearest(p)
+	case geom.CubicCurve:
+		return seg.Nearest(p)
+	}
+	panic("unsupported segment type")
+}
+
+// tangent returns the unit tangent of the given segment at parameter t.
+func tangent(seg geom.PathSegment, t float64) geom.Point {
+	switch seg := seg.(type) {
+	case geom.LineSeg:
+		return seg[1].Sub(seg[0]).Normalize()
+	case geom.QuadraticCurve:
+		return seg.Tangent(t)
+	case geom.CubicCurve:
+		return seg.Tangent(t)
+	}
+	panic("unsupported segment type")
+}
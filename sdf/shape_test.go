@@ -0,0 +1,135 @@
+package sdf
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+var (
+	square = geom.Path(nil).
+		MoveTo(geom.Point{2, 2}).
+		LineTo(geom.Point{8, 2}).
+		LineTo(geom.Point{8, 8}).
+		LineTo(geom.Point{2, 8}).
+		Close()
+
+	circle = geom.Path(nil).
+		MoveTo(geom.Point{9, 5}).
+		ArcTo(geom.Point{4, 4}, 0, false, true, geom.Point{1, 5}).
+		ArcTo(geom.Point{4, 4}, 0, false, true, geom.Point{9, 5}).
+		Close()
+
+	// squareWithHole has an inner boundary running the opposite way.
+	squareWithHole = append(square[:1:1], geom.Path(nil).
+			MoveTo(geom.Point{4, 4}).
+			LineTo(geom.Point{4, 6}).
+			LineTo(geom.Point{6, 6}).
+			LineTo(geom.Point{6, 4}).
+			Close()...)
+
+	// teardrop has only a single corner.
+	teardrop = geom.Path(nil).
+			MoveTo(geom.Point{5, 1}).
+			CubicTo(geom.Point{14, 10}, geom.Point{-4, 10}, geom.Point{5, 1}).
+			Close()
+)
+
+func TestShapeDistance(t *testing.T) {
+	tests := []struct {
+		Path  geom.Path
+		Point geom.Point
+		Want  float64
+	}{
+		{square, geom.Point{5, 5}, 3},
+		{square, geom.Point{3, 5}, 1},
+		{square, geom.Point{0, 5}, -2},
+		{square, geom.Point{10, 10}, -math.Sqrt(8)},
+		{circle, geom.Point{5, 5}, 4},
+		{circle, geom.Point{5, 0}, -1},
+		{circle, geom.Point{8, 8}, 4 - math.Sqrt(18)},
+		{squareWithHole, geom.Point{5, 5}, -1},
+		{squareWithHole, geom.Point{3, 3}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Point), func(t *testing.T) {
+			got := NewShape(test.Path).Distance(test.Point)
+			if math.Abs(got-test.Want) > 2e-3 {
+				t.Errorf("wrong distance %g; want %g", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestShapeMultiDistance(t *testing.T) {
+	s := NewShape(square)
+
+	// Outside of a corner, the median of the channels measures the
+	// distance to the extended edges, preserving the sharp corner.
+	if got, want := Median(s.MultiDistance(geom.Point{10, 10})), -2.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("wrong median at corner %g; want %g", got, want)
+	}
+
+	// Elsewhere, the median has the same sign as the true distance.
+	for name, path := range map[string]geom.Path{
+		"square":         square,
+		"circle":         circle,
+		"squareWithHole": squareWithHole,
+		"teardrop":       teardrop,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := NewShape(path)
+			for y := -1.0; y <= 11; y += 0.25 {
+				for x := -1.0; x <= 11; x += 0.25 {
+					p := geom.Point{x, y}
+					d := s.Distance(p)
+					if math.Abs(d) < 0.01 {
+						continue
+					}
+					if m := Median(s.MultiDistance(p)); (m > 0) != (d > 0) {
+						t.Errorf("wrong sign at %#v: median %g, distance %g", p, m, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestShapeSDF(t *testing.T) {
+	img := NewShape(square).SDF(image.Rect(0, 0, 10, 10), 4)
+	tests := []struct {
+		Pixel image.Point
+		Want  uint8
+	}{
+		{image.Point{5, 5}, 255},
+		{image.Point{2, 5}, 159}, // 0.5 inside
+		{image.Point{1, 5}, 96},  // 0.5 outside
+		{image.Point{0, 0}, 0},
+	}
+	for _, test := range tests {
+		if got := img.GrayAt(test.Pixel.X, test.Pixel.Y).Y; got != test.Want {
+			t.Errorf("wrong value at %s: got %d, want %d", test.Pixel, got, test.Want)
+		}
+	}
+}
+
+func TestShapeMSDF(t *testing.T) {
+	s := NewShape(circle)
+	img := s.MSDF(image.Rect(0, 0, 10, 10), 4)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			c := img.RGBAAt(x, y)
+			d := s.Distance(pixelCenter(x, y))
+			want := encode(d, 4)
+
+			// The circle has no corners, so all channels are the same.
+			if c.R != want || c.G != want || c.B != want || c.A != 255 {
+				t.Errorf("wrong color at (%d, %d): got %#v, want %d", x, y, c, want)
+			}
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package svgpath
 
 import (
+	"iter"
+	"slices"
+
 	"github.com/apparentlymart/go-geometry/geom"
 )
 
@@ -20,6 +23,12 @@ func (p Path) Equal(o Path) bool {
 	return true
 }
 
+// Commands returns an iterator over the commands in the path and their
+// indices, for use with a range statement.
+func (p Path) Commands() iter.Seq2[int, Command] {
+	return slices.All(p)
+}
+
 // MakeAbsolute rewrites any relative steps in the path to be absolute, in place.
 //
 // This method tracks the effect of each command on the sub-path start point
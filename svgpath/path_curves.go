@@ -0,0 +1,34 @@
+package svgpath
+
+import (
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// CubicCurveSeqs converts the receiver into a sequence of cubic bezier curves
+// for each of its sub-paths that contains at least one drawing command.
+//
+// Lines are converted to curves whose control points are at their endpoints,
+// quadratic curves are converted to their exact cubic equivalents, and arcs
+// are approximated using one curve for each quarter-turn. Closing a sub-path
+// adds a line back to its start point, unless it already ends there.
+//
+// Relative commands are interpreted relative to the endpoint of the previous
+// command, as described in the SVG specification. The result is therefore
+// the same whether or not the path has been made absolute first.
+func (p Path) CubicCurveSeqs() []geom.CubicCurveSeq {
+	return p.GeomPath().CubicCurveSeqs()
+}
+
+// ConvexHull returns a convex polygon that contains the entire path, which
+// is no further than the given tolerance from its true convex hull.
+//
+// The result has the same properties as that of geom.ConvexHull. See the
+// ConvexHull method of geom.CubicCurveSeq for details on how curves are
+// treated.
+func (p Path) ConvexHull(tolerance float64) geom.Poly {
+	var pts []geom.Point
+	for _, seq := range p.CubicCurveSeqs() {
+		pts = append(pts, seq.ConvexHull(tolerance)...)
+	}
+	return geom.ConvexHull(pts)
+}
@@ -0,0 +1,210 @@
+package svgpath
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+
+	"github.com/go-test/deep"
+)
+
+func TestPathCubicCurveSeqs(t *testing.T) {
+	tests := []struct {
+		Path Path
+		Want []geom.CubicCurveSeq
+	}{
+		{
+			nil,
+			nil,
+		},
+		{
+			Path{
+				Move(geom.Point{10, 10}),
+			},
+			nil,
+		},
+		{
+			Path{
+				Move(geom.Point{10, 10}),
+				Line(geom.Point{20, 10}),
+				LineRel(geom.Point{0, 10}),
+				Close,
+			},
+			[]geom.CubicCurveSeq{
+				{
+					{10, 10},
+					{10, 10}, {20, 10}, {20, 10},
+					{20, 10}, {20, 20}, {20, 20},
+					{20, 20}, {10, 10}, {10, 10},
+				},
+			},
+		},
+		{
+			Path{
+				MoveRel(geom.Point{10, 10}),
+				HorizLineRel(5),
+				VertLine(0),
+				Close,
+				LineRel(geom.Point{-10, 0}),
+			},
+			[]geom.CubicCurveSeq{
+				{
+					{10, 10},
+					{10, 10}, {15, 10}, {15, 10},
+					{15, 10}, {15, 0}, {15, 0},
+					{15, 0}, {10, 10}, {10, 10},
+				},
+				{
+					{10, 10},
+					{10, 10}, {0, 10}, {0, 10},
+				},
+			},
+		},
+		{
+			Path{
+				Move(geom.Point{0, 0}),
+				Curve(geom.Point{0, 10}, geom.Point{10, 10}, geom.Point{10, 0}),
+				SmoothCurveRel(geom.Point{10, -10}, geom.Point{10, 0}),
+			},
+			[]geom.CubicCurveSeq{
+				{
+					{0, 0},
+					{0, 10}, {10, 10}, {10, 0},
+					{10, -10}, {20, -10}, {20, 0},
+				},
+			},
+		},
+		{
+			Path{
+				Move(geom.Point{0, 0}),
+				QuadCurve(geom.Point{3, 3}, geom.Point{6, 0}),
+				SmoothQuadCurve(geom.Point{12, 0}),
+			},
+			[]geom.CubicCurveSeq{
+				{
+					{0, 0},
+					{2, 2}, {4, 2}, {6, 0},
+					{8, -2}, {10, -2}, {12, 0},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Path), func(t *testing.T) {
+			got := test.Path.CubicCurveSeqs()
+
+			for _, problem := range deep.Equal(got, test.Want) {
+				t.Error(problem)
+			}
+		})
+	}
+}
+
+func TestPathCubicCurveSeqsArc(t *testing.T) {
+	// A full circle of radius 10 around the origin, drawn as two arcs.
+	path, err := Parse(`M 10,0 A 10,10 0 0 1 -10,0 A 10,10 0 0 1 10,0 Z`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	seqs := path.CubicCurveSeqs()
+	if len(seqs) != 1 {
+		t.Fatalf("wrong number of sequences %d; want 1", len(seqs))
+	}
+
+	count := 0
+	for it := seqs[0].Iterator(); it.Next(); {
+		c := it.CubicCurve()
+		count++
+		for i := 0; i <= 10; i++ {
+			p := bezierPoint(c, float64(i)/10)
+			if r := math.Hypot(p.X, p.Y); math.Abs(r-10) > 0.01 {
+				t.Errorf("curve %d at %f is at radius %f; want 10", count, float64(i)/10, r)
+			}
+		}
+	}
+	if count != 4 {
+		t.Errorf("wrong number of curves %d; want 4", count)
+	}
+
+	hull := path.ConvexHull(0.001)
+	for _, p := range hull {
+		if r := math.Hypot(p.X, p.Y); r < 9.99 || r > 10.01 {
+			t.Errorf("hull point %#v is at radius %f; want 10", p, r)
+		}
+	}
+}
+
+func bezierPoint(c geom.CubicCurve, t float64) geom.Point {
+	mt := 1 - t
+	return c[0].Scale(mt * mt * mt).
+		Add(c[1].Scale(3 * mt * mt * t)).
+		Add(c[2].Scale(3 * mt * t * t)).
+		Add(c[3].Scale(t * t * t))
+}
+
+func TestPathGeomPath(t *testing.T) {
+	tests := []struct {
+		Path Path
+		Want geom.Path
+	}{
+		{
+			nil,
+			nil,
+		},
+		{
+			Path{
+				MoveRel(geom.Point{10, 10}),
+				HorizLineRel(5),
+				QuadCurve(geom.Point{20, 10}, geom.Point{20, 20}),
+				SmoothQuadCurveRel(geom.Point{0, 10}),
+				Close,
+				ArcRel(geom.Point{5, 5}, 90, false, true, geom.Point{10, 0}),
+			},
+			geom.Path{
+				{
+					Start: geom.Point{10, 10},
+					Segments: []geom.PathSegment{
+						geom.LineSeg{{10, 10}, {15, 10}},
+						geom.QuadraticCurve{{15, 10}, {20, 10}, {20, 20}},
+						geom.QuadraticCurve{{20, 20}, {20, 30}, {20, 30}},
+					},
+					Closed: true,
+				},
+				{
+					Start: geom.Point{10, 10},
+					Segments: []geom.PathSegment{
+						geom.Arc{
+							From:     geom.Point{10, 10},
+							To:       geom.Point{20, 10},
+							Radii:    geom.Point{5, 5},
+							Rotation: math.Pi / 2,
+							Sweep:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			got := test.Path.GeomPath()
+			if diff := deep.Equal(got, test.Want); diff != nil {
+				for _, problem := range diff {
+					t.Error(problem)
+				}
+			}
+
+			// Converting back should give an equivalent absolute path.
+			back := FromGeomPath(got).GeomPath()
+			if diff := deep.Equal(back, got); diff != nil {
+				for _, problem := range diff {
+					t.Errorf("round trip: %s", problem)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package svgpath
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// GeomPath converts the receiver into a geom.Path, with a contour for each
+// of its sub-paths.
+//
+// Horizontal and vertical lines become line segments, smooth curves become
+// curves with their first control points found by reflection, and arcs
+// become geom.Arc segments with their rotations converted to radians.
+// Relative commands are interpreted relative to the endpoint of the previous
+// command, as described in the SVG specification. The result is therefore
+// the same whether or not the path has been made absolute first.
+func (p Path) GeomPath() geom.Path {
+	var ret geom.Path
+
+	// ctrl is the most recent control point, which the smooth curve
+	// instructions reflect to find their first control point. It is
+	// meaningful only if prev is one of the corresponding curve instructions.
+	var ctrl geom.Point
+	var prev Instruction
+
+	for _, cmd := range p {
+		cur := ret.CurrentPoint()
+		pt := func(x, y float64) geom.Point {
+			if cmd.Inst.Relative() {
+				return geom.Point{X: cur.X + x, Y: cur.Y + y}
+			}
+			return geom.Point{X: x, Y: y}
+		}
+		a := cmd.Args
+		abs := cmd.Inst.ToAbsolute()
+		switch abs {
+		case MoveTo:
+			ret = ret.MoveTo(pt(a[0], a[1]))
+		case ClosePath:
+			ret = ret.Close()
+		case LineTo:
+			ret = ret.LineTo(pt(a[0], a[1]))
+		case HorizLineTo:
+			end := geom.Point{X: a[0], Y: cur.Y}
+			if cmd.Inst.Relative() {
+				end.X += cur.X
+			}
+			ret = ret.LineTo(end)
+		case VertLineTo:
+			end := geom.Point{X: cur.X, Y: a[0]}
+			if cmd.Inst.Relative() {
+				end.Y += cur.Y
+			}
+			ret = ret.LineTo(end)
+		case CurveTo, SmoothCurveTo:
+			c1 := cur
+			if abs == CurveTo {
+				c1 = pt(a[0], a[1])
+				a = a[2:]
+			} else if prev == CurveTo || prev == SmoothCurveTo {
+				c1 = cur.Add(cur.Sub(ctrl))
+			}
+			c2 := pt(a[0], a[1])
+			ret = ret.CubicTo(c1, c2, pt(a[2], a[3]))
+			ctrl = c2
+		case QuadCurveTo, SmoothQuadCurveTo:
+			c := cur
+			if abs == QuadCurveTo {
+				c = pt(a[0], a[1])
+				a = a[2:]
+			} else if prev == QuadCurveTo || prev == SmoothQuadCurveTo {
+				c = cur.Add(cur.Sub(ctrl))
+			}
+			ret = ret.QuadTo(c, pt(a[0], a[1]))
+			ctrl = c
+		case ArcTo:
+			radii := geom.Point{X: a[0], Y: a[1]}
+			ret = ret.ArcTo(radii, a[2]*math.Pi/180, a[3] != 0, a[4] != 0, pt(a[5], a[6]))
+		default:
+			panic(fmt.Sprintf("GeomPath with invalid instruction %s", cmd.Inst))
+		}
+		prev = abs
+	}
+	return ret
+}
+
+// FromGeomPath converts a geom.Path into an equivalent path using only
+// absolute instructions.
+//
+// Each contour begins with a move instruction, and closed contours end with
+// a close instruction. Arcs are converted with their rotations in degrees,
+// as SVG requires.
+func FromGeomPath(gp geom.Path) Path {
+	var ret Path
+	for _, c := range gp {
+		ret = append(ret, Move(c.Start))
+		for _, seg := range c.Segments {
+			switch s := seg.(type) {
+			case geom.LineSeg:
+				ret = append(ret, Line(s[1]))
+			case geom.QuadraticCurve:
+				ret = append(ret, QuadCurve(s[1], s[2]))
+			case geom.CubicCurve:
+				ret = append(ret, Curve(s[1], s[2], s[3]))
+			case geom.Arc:
+				ret = append(ret, Arc(s.Radii, s.Rotation*180/math.Pi, s.LargeArc, s.Sweep, s.To))
+			}
+		}
+		if c.Closed {
+			ret = append(ret, Close)
+		}
+	}
+	return ret
+}
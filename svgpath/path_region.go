@@ -0,0 +1,57 @@
+package svgpath
+
+import (
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Rings converts each of the receiver's sub-paths into a polygon, by
+// approximating its curves with line segments that are no further than the
+// given tolerance from the true curve.
+//
+// Sub-paths are treated as closed whether or not they end with a close
+// instruction, as they are when an SVG path is filled.
+func (p Path) Rings(tolerance float64) []geom.Poly {
+	seqs := p.CubicCurveSeqs()
+	ret := make([]geom.Poly, 0, len(seqs))
+	for _, seq := range seqs {
+		ring := geom.Poly(seq.Flatten(tolerance))
+		if len(ring) > 1 && ring[len(ring)-1] == ring[0] {
+			ring = ring[:len(ring)-1]
+		}
+		ret = append(ret, ring)
+	}
+	return ret
+}
+
+// Region returns the region that would be filled when drawing the receiver
+// with the given fill rule, approximating its curves as described for
+// method Rings.
+//
+// The sub-paths of the receiver must not cross themselves or one another,
+// although they may be nested inside one another. This is true, for
+// example, of the outlines of most font glyphs.
+func (p Path) Region(tolerance float64, rule geom.FillRule) geom.Region {
+	return geom.NewRegion(p.Rings(tolerance), rule)
+}
+
+// NestSubpaths finds how the given sub-paths are nested inside one another,
+// approximating their curves as described for method Path.Rings. Each node
+// in the result corresponds to the sub-path at the same index, and so its
+// role under each fill rule can be found using method Roles.
+//
+// Each given path should be a single sub-path as returned by method
+// Path.Subpaths. A sub-path that begins with a relative move instruction is
+// interpreted relative to the origin, since the end of the preceding
+// sub-path isn't known. A sub-path without any drawing instructions
+// encloses no area, and so is a root of the tree with no children.
+//
+// The sub-paths must not cross themselves or one another.
+func NestSubpaths(subpaths []Path, tolerance float64) *geom.RingTree {
+	rings := make([]geom.Poly, len(subpaths))
+	for i, sp := range subpaths {
+		if r := sp.Rings(tolerance); len(r) > 0 {
+			rings[i] = r[0]
+		}
+	}
+	return geom.NestRings(rings)
+}
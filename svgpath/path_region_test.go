@@ -0,0 +1,83 @@
+package svgpath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+
+	"github.com/go-test/deep"
+)
+
+func TestPathRings(t *testing.T) {
+	p := Path{
+		Move(geom.Point{0, 0}),
+		Line(geom.Point{10, 0}),
+		Line(geom.Point{10, 10}),
+		Line(geom.Point{0, 10}),
+		Close,
+		Move(geom.Point{2, 2}),
+		Line(geom.Point{2, 8}),
+		Line(geom.Point{8, 8}),
+		Line(geom.Point{8, 2}),
+	}
+	got := p.Rings(0.1)
+	want := []geom.Poly{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		{{2, 2}, {2, 8}, {8, 8}, {8, 2}},
+	}
+
+	for _, problem := range deep.Equal(got, want) {
+		t.Error(problem)
+	}
+}
+
+func TestPathRegion(t *testing.T) {
+	// A circle of radius 10 with a square hole, in the style of a font
+	// glyph where the counter runs in the opposite direction.
+	p, err := Parse("M 0,-10 A 10,10 0 0 1 0,10 A 10,10 0 0 1 0,-10 Z M -2,-2 L -2,2 L 2,2 L 2,-2 Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, rule := range []geom.FillRule{geom.NonZero, geom.EvenOdd} {
+		got := p.Region(0.001, rule)
+		if len(got) != 1 {
+			t.Fatalf("wrong number of parts %d; want 1", len(got))
+		}
+		if len(got[0].Holes) != 1 {
+			t.Fatalf("wrong number of holes %d; want 1", len(got[0].Holes))
+		}
+		want := math.Pi*100 - 16
+		if diff := math.Abs(got.Area() - want); diff > 0.1 {
+			t.Errorf("wrong area %f; want %f", got.Area(), want)
+		}
+		for _, v := range got[0].Outer {
+			// The cubic approximation of each arc is itself slightly
+			// outside the true circle.
+			if r := math.Hypot(v.X, v.Y); math.Abs(r-10) > 0.01 {
+				t.Errorf("vertex %#v is at distance %f from the center; want 10", v, r)
+			}
+		}
+	}
+}
+
+func TestNestSubpaths(t *testing.T) {
+	p, err := Parse("M 0,0 H 10 V 10 H 0 Z M 2,2 V 8 H 8 V 2 Z M 20,20")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	subpaths := p.Subpaths()
+
+	tree := NestSubpaths(subpaths, 0.1)
+	if got, want := len(tree.Nodes), len(subpaths); got != want {
+		t.Fatalf("wrong number of nodes %d; want %d", got, want)
+	}
+	for _, problem := range deep.Equal(tree.Roots, []int{0, 2}) {
+		t.Error(problem)
+	}
+	wantRoles := []geom.RingRole{geom.OuterRing, geom.HoleRing, geom.NotBoundary}
+	for _, problem := range deep.Equal(tree.Roles(geom.NonZero), wantRoles) {
+		t.Error(problem)
+	}
+}
@@ -194,3 +194,21 @@ func TestPathSubpaths(t *testing.T) {
 		})
 	}
 }
+
+func TestPathCommands(t *testing.T) {
+	p := Path{
+		Move(geom.Point{0, 0}),
+		Line(geom.Point{10, 0}),
+		Close,
+	}
+	var got Path
+	for i, cmd := range p.Commands() {
+		if i != len(got) {
+			t.Errorf("wrong index %d; want %d", i, len(got))
+		}
+		got = append(got, cmd)
+	}
+	if !got.Equal(p) {
+		t.Errorf("wrong commands\ngot:  %#v\nwant: %#v", got, p)
+	}
+}
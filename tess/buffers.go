@@ -0,0 +1,24 @@
+package tess
+
+// Buffers is a set of triangles that share vertices of type V.
+type Buffers[V any] struct {
+	Vertices []V
+
+	// Indices has three elements for each triangle, each of which is an
+	// index into Vertices.
+	Indices []uint32
+}
+
+// Triangles returns the number of triangles in the buffers.
+func (b Buffers[V]) Triangles() int {
+	return len(b.Indices) / 3
+}
+
+// Triangle returns the vertices of the triangle with the given index.
+func (b Buffers[V]) Triangle(i int) [3]V {
+	return [3]V{
+		b.Vertices[b.Indices[3*i]],
+		b.Vertices[b.Indices[3*i+1]],
+		b.Vertices[b.Indices[3*i+2]],
+	}
+}
@@ -0,0 +1,10 @@
+// Package tess converts paths into triangles, in the form of vertex and
+// index buffers suitable for drawing with a GPU.
+//
+// Curves are flattened to a given tolerance before tessellating. Paths from
+// package svgpath can be tessellated after converting them with their
+// GeomPath method.
+//
+// The results depend only on the input, and so are suitable for comparing
+// against previously-recorded results in tests.
+package tess
@@ -0,0 +1,54 @@
+package tess
+
+import (
+	"github.com/apparentlymart/go-geometry/delaunay"
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// Fill produces triangles covering the interior of the given path under
+// the given fill rule, flattening any curves to the given tolerance. Each
+// contour of the path is treated as closed, whether or not it is closed in
+// the path.
+//
+// The triangles are a constrained Delaunay triangulation, with the vertices
+// of each triangle in anti-clockwise order assuming a Y axis that increases
+// upward. Additional vertices are added where edges of the path cross.
+//
+// An error is returned if crossing edges meet at a point that can't be
+// represented.
+func Fill(p geom.Path, rule geom.FillRule, tolerance float64) (Buffers[geom.Point], error) {
+	shape := delaunay.Shape{
+		NonZero: rule != geom.EvenOdd,
+	}
+	for _, c := range p {
+		if ring := fillRing(c.Flatten(tolerance)); ring != nil {
+			shape.Boundaries = append(shape.Boundaries, ring)
+		}
+	}
+	if len(shape.Boundaries) == 0 {
+		return Buffers[geom.Point]{}, nil
+	}
+
+	mesh, err := delaunay.Constrained(shape, nil)
+	if err != nil {
+		return Buffers[geom.Point]{}, err
+	}
+	ret := Buffers[geom.Point]{
+		Vertices: mesh.Points,
+		Indices:  make([]uint32, 0, 3*len(mesh.Triangles)),
+	}
+	for _, t := range mesh.Triangles {
+		ret.Indices = append(ret.Indices, uint32(t[0]), uint32(t[1]), uint32(t[2]))
+	}
+	return ret, nil
+}
+
+// fillRing returns the distinct consecutive points of the given closed
+// sequence as a polygon, or nil if there are fewer than three of them.
+func fillRing(seq geom.LineSegSeq) geom.Poly {
+	ret := geom.Poly(seq.Distinct(true))
+	if len(ret) < 3 {
+		return nil
+	}
+	return ret
+}
@@ -0,0 +1,135 @@
+package tess
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+	"github.com/apparentlymart/go-geometry/svgpath"
+
+	"github.com/go-test/deep"
+)
+
+func TestFill(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Path     string
+		Rule     geom.FillRule
+		WantArea float64
+	}{
+		{
+			"empty",
+			"",
+			geom.NonZero,
+			0,
+		},
+		{
+			"degenerate",
+			"M 0 0 L 1 1 Z",
+			geom.NonZero,
+			0,
+		},
+		{
+			"square",
+			"M 0 0 H 4 V 4 H 0 Z",
+			geom.NonZero,
+			16,
+		},
+		{
+			"overlapping non-zero",
+			"M 0 0 H 4 V 4 H 0 Z M 2 2 H 6 V 6 H 2 Z",
+			geom.NonZero,
+			28,
+		},
+		{
+			"overlapping even-odd",
+			"M 0 0 H 4 V 4 H 0 Z M 2 2 H 6 V 6 H 2 Z",
+			geom.EvenOdd,
+			24,
+		},
+		{
+			"hole non-zero",
+			"M 0 0 H 6 V 6 H 0 Z M 2 2 V 4 H 4 V 2 Z",
+			geom.NonZero,
+			32,
+		},
+		{
+			"nested same direction non-zero",
+			"M 0 0 H 6 V 6 H 0 Z M 2 2 H 4 V 4 H 2 Z",
+			geom.NonZero,
+			36,
+		},
+		{
+			"nested same direction even-odd",
+			"M 0 0 H 6 V 6 H 0 Z M 2 2 H 4 V 4 H 2 Z",
+			geom.EvenOdd,
+			32,
+		},
+		{
+			"self-intersecting bowtie",
+			"M 0 0 L 4 4 V 0 L 0 4 Z",
+			geom.NonZero,
+			8,
+		},
+		{
+			"circle",
+			"M 4 0 A 4 4 0 0 1 -4 0 A 4 4 0 0 1 4 0",
+			geom.NonZero,
+			math.Pi * 16, // approximately, since arcs become cubic curves
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			path, err := svgpath.Parse(test.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Fill(path.GeomPath(), test.Rule, 1e-4)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var area float64
+			for i := 0; i < got.Triangles(); i++ {
+				tri := got.Triangle(i)
+				a := tri[1].Sub(tri[0]).Cross(tri[2].Sub(tri[0])) / 2
+				if a <= 0 {
+					t.Errorf("triangle %d %#v is not anti-clockwise", i, tri)
+				}
+				area += a
+			}
+			if math.Abs(area-test.WantArea) > 0.02 {
+				t.Errorf("wrong total area %g; want %g", area, test.WantArea)
+			}
+		})
+	}
+}
+
+func TestFillDeterministic(t *testing.T) {
+	path := geom.Path(nil).
+		MoveTo(geom.Point{0, 0}).
+		LineTo(geom.Point{2, 0}).
+		LineTo(geom.Point{2, 2}).
+		LineTo(geom.Point{1, 1}).
+		LineTo(geom.Point{0, 2}).
+		Close()
+
+	got, err := Fill(path, geom.NonZero, 0.1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := Buffers[geom.Point]{
+		Vertices: []geom.Point{{0, 0}, {2, 0}, {2, 2}, {1, 1}, {0, 2}},
+		Indices: []uint32{
+			4, 0, 3,
+			1, 2, 3,
+			1, 3, 0,
+		},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		for _, problem := range diff {
+			t.Error(problem)
+		}
+	}
+}
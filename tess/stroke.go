@@ -0,0 +1,275 @@
+package tess
+
+import (
+	"math"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+// StrokeVertex is a vertex produced by Stroke.
+type StrokeVertex struct {
+	Point geom.Point
+
+	// Distance is the distance along the contour, from its start, of the
+	// point on the path that the vertex was produced from. Caps extend
+	// beyond the ends of the contour, so their vertices have distances
+	// before its start or after its end.
+	//
+	// Dashes can be drawn by shading based on this distance.
+	Distance float64
+
+	// Side is the offset of the vertex from the path across the stroke, as
+	// a fraction of half of the stroke width. It is positive to the left of
+	// the path, assuming a Y axis that increases upward, and zero for
+	// vertices on the path itself.
+	Side float64
+}
+
+// Stroke produces triangles covering the area of the given path when
+// stroked with the given style, flattening any curves and approximating any
+// round joins and caps to the given tolerance.
+//
+// The triangles are produced directly from the segments of the path, with
+// the vertices of each in anti-clockwise order assuming a Y axis that
+// increases upward. The triangles for adjacent segments and joins may
+// overlap, so drawing them with a translucent color requires a depth or
+// stencil test to avoid blending the overlapping parts twice. A segment
+// shares its end vertices with the joins and caps that meet it, and with a
+// following segment that continues in the same direction, wherever their
+// distances along the contour agree.
+//
+// The dashes of the style are ignored, since they can instead be drawn
+// using the Distance of each vertex. The result is empty if the width of the
+// style or the tolerance isn't greater than zero.
+func Stroke(p geom.Path, style geom.StrokeStyle, tolerance float64) Buffers[StrokeVertex] {
+	s := stroker{
+		style:     style,
+		hw:        style.Width / 2,
+		tolerance: tolerance,
+	}
+	if s.hw <= 0 || tolerance <= 0 {
+		return s.ret
+	}
+	for _, c := range p {
+		if len(c.Segments) == 0 {
+			continue
+		}
+		s.contour(c.Flatten(tolerance).Distinct(c.Closed), c.Closed)
+	}
+	return s.ret
+}
+
+type stroker struct {
+	style     geom.StrokeStyle
+	hw        float64
+	tolerance float64
+	ret       Buffers[StrokeVertex]
+}
+
+func (s *stroker) contour(pts []geom.Point, closed bool) {
+	if len(pts) == 1 {
+		s.point(pts[0])
+		return
+	}
+
+	dists := make([]float64, len(pts)+1)
+	for i := range pts {
+		dists[i+1] = dists[i] + pts[i].Dist(pts[(i+1)%len(pts)])
+	}
+
+	n := len(pts) - 1
+	if closed {
+		n = len(pts)
+	}
+	normal := func(i int) geom.Point {
+		return pts[(i+1)%len(pts)].Sub(pts[i]).Normalize().Perp()
+	}
+
+	// ends holds the vertices on the right and left of each segment at its
+	// start and end, which are shared with the joins and caps there.
+	ends := make([]segmentEnds, n)
+	for i := range ends {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		off := normal(i).Scale(s.hw)
+		if i > 0 && normal(i) == normal(i-1) {
+			// A segment continuing straight on from the previous one can
+			// start where that one ends.
+			ends[i].start = ends[i-1].end
+		} else {
+			ends[i].start = [2]uint32{
+				s.vertex(a.Sub(off), dists[i], -1),
+				s.vertex(a.Add(off), dists[i], 1),
+			}
+		}
+		ends[i].end = [2]uint32{
+			s.vertex(b.Sub(off), dists[i+1], -1),
+			s.vertex(b.Add(off), dists[i+1], 1),
+		}
+		s.quad(ends[i].start[0], ends[i].end[0], ends[i].end[1], ends[i].start[1])
+	}
+
+	for i := range ends {
+		if i == n-1 && !closed {
+			break
+		}
+		d := dists[i+1]
+		if i == n-1 {
+			// The join at the start of a closed contour belongs with the
+			// start rather than the end.
+			d = 0
+		}
+		j := (i + 1) % n
+		s.join(pts[j], d, normal(i), normal(j), ends[i].end, ends[j].start)
+	}
+
+	if !closed {
+		s.cap(pts[0], dists[0], normal(0), true, ends[0].start)
+		s.cap(pts[len(pts)-1], dists[len(pts)-1], normal(len(pts)-2), false, ends[n-1].end)
+	}
+}
+
+// segmentEnds holds the indices of the vertices at each end of a stroked
+// segment, on its right and then its left.
+type segmentEnds struct {
+	start, end [2]uint32
+}
+
+// join fills the gap on the outside of the turn at point v between segments
+// with the given unit normals, using the polygon from the style's JoinPoly.
+// prev and next are the vertices at the end of the segment before v and the
+// start of the segment after it, which the join shares where their
+// distances match.
+func (s *stroker) join(v geom.Point, dist float64, n0, n1 geom.Point, prev, next [2]uint32) {
+	poly := s.style.JoinPoly(v, n0.Scale(s.hw), n1.Scale(s.hw), s.tolerance)
+	if len(poly) < 3 {
+		return
+	}
+
+	// The whole join is on the side of the path where it starts, and its
+	// vertices are further than half of the stroke width from v only at
+	// the tip of a miter.
+	side := math.Copysign(1, poly[1].Sub(v).Dot(n0))
+	vertex := func(p geom.Point) uint32 {
+		return s.vertex(p, dist, side*p.Dist(v)/s.hw)
+	}
+	k := 0
+	if side > 0 {
+		k = 1
+	}
+	shared := func(i uint32, p geom.Point) uint32 {
+		if s.ret.Vertices[i].Distance == dist {
+			return i
+		}
+		return vertex(p)
+	}
+
+	center := s.vertex(v, dist, 0)
+	first, last := shared(prev[k], poly[1]), shared(next[k], poly[len(poly)-1])
+	a := first
+	for _, p := range poly[2 : len(poly)-1] {
+		b := vertex(p)
+		s.tri(center, a, b)
+		a = b
+	}
+	s.tri(center, a, last)
+}
+
+// cap adds the cap at point p of a contour whose segment there has the
+// given unit normal. start is true for the cap at the start of the
+// contour. ends are the vertices on the right and left of the segment at
+// p, which the cap shares.
+func (s *stroker) cap(p geom.Point, dist float64, nv geom.Point, start bool, ends [2]uint32) {
+	// fwd is the direction of travel along the contour.
+	fwd := nv.Perp().Scale(-1)
+	switch s.style.Cap {
+	case geom.RoundCap:
+		from, first, last := nv, ends[1], ends[0]
+		if !start {
+			from, first, last = nv.Scale(-1), ends[0], ends[1]
+		}
+		s.fan(s.vertex(p, dist, 0), first, last, p, from, math.Pi, func(off geom.Point) (float64, float64) {
+			return dist + off.Dot(fwd)*s.hw, off.Dot(nv)
+		})
+	case geom.SquareCap:
+		ext := fwd.Scale(s.hw)
+		extDist := dist + s.hw
+		if start {
+			ext = ext.Scale(-1)
+			extDist = dist - s.hw
+		}
+		side := nv.Scale(s.hw)
+		s.quad(
+			ends[1],
+			s.vertex(p.Add(side).Add(ext), extDist, 1),
+			s.vertex(p.Sub(side).Add(ext), extDist, -1),
+			ends[0],
+		)
+	}
+}
+
+// point adds the caps of a contour of zero length at point p, which are
+// aligned with the X axis as if the contour were travelling along it.
+func (s *stroker) point(p geom.Point) {
+	switch s.style.Cap {
+	case geom.RoundCap:
+		first := s.vertex(p.Add(geom.Point{X: s.hw}), s.hw, 0)
+		s.fan(s.vertex(p, 0, 0), first, first, p, geom.Point{X: 1}, 2*math.Pi, func(off geom.Point) (float64, float64) {
+			return off.X * s.hw, off.Y
+		})
+	case geom.SquareCap:
+		s.quad(
+			s.vertex(p.Add(geom.Point{X: -s.hw, Y: -s.hw}), -s.hw, -1),
+			s.vertex(p.Add(geom.Point{X: s.hw, Y: -s.hw}), s.hw, -1),
+			s.vertex(p.Add(geom.Point{X: s.hw, Y: s.hw}), s.hw, 1),
+			s.vertex(p.Add(geom.Point{X: -s.hw, Y: s.hw}), -s.hw, 1),
+		)
+	}
+}
+
+// fan adds triangles around the vertex at index center, which is at point
+// c, approximating a circular arc that starts at c+from*hw and turns
+// through the given angle. first and last are the existing vertices at the
+// ends of the arc. The attrs function returns the distance and side
+// attributes for the vertex at c+off*hw, where off is a unit vector.
+func (s *stroker) fan(center, first, last uint32, c, from geom.Point, angle float64, attrs func(off geom.Point) (float64, float64)) {
+	steps := geom.ArcSteps(angle, s.hw, s.tolerance)
+
+	prev := first
+	for k := 1; k < steps; k++ {
+		off := from.Rotate(angle * float64(k) / float64(steps))
+		dist, side := attrs(off)
+		next := s.vertex(c.Add(off.Scale(s.hw)), dist, side)
+		s.tri(center, prev, next)
+		prev = next
+	}
+	s.tri(center, prev, last)
+}
+
+func (s *stroker) vertex(p geom.Point, dist, side float64) uint32 {
+	s.ret.Vertices = append(s.ret.Vertices, StrokeVertex{
+		Point:    p,
+		Distance: dist,
+		Side:     side,
+	})
+	return uint32(len(s.ret.Vertices) - 1)
+}
+
+// tri adds a triangle with the given vertices, reordering them if necessary
+// so that they are anti-clockwise. Triangles with no area are discarded.
+func (s *stroker) tri(a, b, c uint32) {
+	vs := s.ret.Vertices
+	pa, pb, pc := vs[a].Point, vs[b].Point, vs[c].Point
+	switch o := pb.Sub(pa).Cross(pc.Sub(pa)); {
+	case o > 0:
+		s.ret.Indices = append(s.ret.Indices, a, b, c)
+	case o < 0:
+		s.ret.Indices = append(s.ret.Indices, a, c, b)
+	}
+}
+
+// quad adds two triangles covering the convex quadrilateral with the given
+// vertices in order around it.
+func (s *stroker) quad(a, b, c, d uint32) {
+	s.tri(a, b, c)
+	s.tri(a, c, d)
+}
@@ -0,0 +1,188 @@
+package tess
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/apparentlymart/go-geometry/geom"
+)
+
+func TestStroke(t *testing.T) {
+	// An L shape, a closed triangle, a single point and a path with all of
+	// those as separate contours, whose triangles should cover the same
+	// total area as the pieces produced by StrokeStyle.Polys.
+	paths := map[string]geom.Path{
+		"open": geom.Path(nil).
+			MoveTo(geom.Point{0, 0}).
+			LineTo(geom.Point{10, 0}).
+			LineTo(geom.Point{10, 10}),
+		"closed": geom.Path(nil).
+			MoveTo(geom.Point{0, 0}).
+			LineTo(geom.Point{10, 0}).
+			LineTo(geom.Point{0, 10}).
+			Close(),
+		"point": geom.Path(nil).
+			MoveTo(geom.Point{1, 1}).
+			LineTo(geom.Point{1, 1}),
+		"multiple": geom.Path(nil).
+			MoveTo(geom.Point{0, 0}).
+			LineTo(geom.Point{10, 0}).
+			LineTo(geom.Point{10, 10}).
+			MoveTo(geom.Point{20, 0}).
+			LineTo(geom.Point{30, 0}).
+			LineTo(geom.Point{20, 10}).
+			Close().
+			MoveTo(geom.Point{40, 1}).
+			LineTo(geom.Point{40, 1}),
+	}
+	styles := []geom.StrokeStyle{
+		{Width: 2, Cap: geom.ButtCap, Join: geom.BevelJoin},
+		{Width: 2, Cap: geom.SquareCap, Join: geom.MiterJoin},
+		{Width: 2, Cap: geom.RoundCap, Join: geom.RoundJoin},
+		{Width: 2, Join: geom.MiterJoin, MiterLimit: 1.1},
+	}
+
+	for name, path := range paths {
+		for _, style := range styles {
+			t.Run(fmt.Sprintf("%s %#v", name, style), func(t *testing.T) {
+				got := Stroke(path, style, 0.01)
+
+				var area float64
+				for i := 0; i < got.Triangles(); i++ {
+					tri := got.Triangle(i)
+					a := tri[1].Point.Sub(tri[0].Point).Cross(tri[2].Point.Sub(tri[0].Point)) / 2
+					if a <= 0 {
+						t.Errorf("triangle %d %#v is not anti-clockwise", i, tri)
+					}
+					area += a
+				}
+
+				// Vertices are shared rather than repeated, and all of
+				// them are used.
+				used := make([]bool, len(got.Vertices))
+				for _, i := range got.Indices {
+					used[i] = true
+				}
+				seen := make(map[StrokeVertex]bool)
+				for i, v := range got.Vertices {
+					if seen[v] {
+						t.Errorf("vertex %d %#v is repeated", i, v)
+					}
+					seen[v] = true
+					if !used[i] {
+						t.Errorf("vertex %d %#v is unused", i, v)
+					}
+				}
+
+				var want float64
+				for _, c := range path {
+					for _, p := range style.Polys(c.Flatten(0.01), c.Closed, 0.01) {
+						want += p.Area()
+					}
+				}
+				if math.Abs(area-want) > 1e-9 {
+					t.Errorf("wrong total area %g; want %g", area, want)
+				}
+			})
+		}
+	}
+}
+
+func TestStrokeSharedVertices(t *testing.T) {
+	tests := []struct {
+		Path geom.Path
+		Want int
+	}{
+		{
+			// Two segments and the center of the join between them
+			geom.Path(nil).
+				MoveTo(geom.Point{0, 0}).
+				LineTo(geom.Point{10, 0}).
+				LineTo(geom.Point{10, 10}),
+			9,
+		},
+		{
+			// The second segment continues straight on from the first
+			geom.Path(nil).
+				MoveTo(geom.Point{0, 0}).
+				LineTo(geom.Point{5, 0}).
+				LineTo(geom.Point{10, 0}),
+			6,
+		},
+	}
+
+	style := geom.StrokeStyle{Width: 2, Cap: geom.ButtCap, Join: geom.BevelJoin}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Path), func(t *testing.T) {
+			got := Stroke(test.Path, style, 0.01)
+			if len(got.Vertices) != test.Want {
+				t.Errorf("got %d vertices; want %d", len(got.Vertices), test.Want)
+			}
+		})
+	}
+}
+
+func TestStrokeTolerance(t *testing.T) {
+	path := geom.Path(nil).
+		MoveTo(geom.Point{0, 0}).
+		LineTo(geom.Point{10, 0})
+	style := geom.StrokeStyle{Width: 2, Cap: geom.RoundCap}
+
+	for _, tolerance := range []float64{0, -1} {
+		t.Run(fmt.Sprintf("%g", tolerance), func(t *testing.T) {
+			if got := Stroke(path, style, tolerance); got.Triangles() != 0 {
+				t.Errorf("got %d triangles; want none", got.Triangles())
+			}
+		})
+	}
+}
+
+func TestStrokeAttributes(t *testing.T) {
+	path := geom.Path(nil).
+		MoveTo(geom.Point{0, 0}).
+		LineTo(geom.Point{10, 0})
+
+	tests := []struct {
+		Cap       geom.LineCap
+		WantDists []float64
+	}{
+		{geom.ButtCap, []float64{0, 10}},
+		{geom.SquareCap, []float64{-1, 0, 10, 11}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%#v", test.Cap), func(t *testing.T) {
+			got := Stroke(path, geom.StrokeStyle{Width: 2, Cap: test.Cap}, 0.01)
+
+			var dists []float64
+			for _, v := range got.Vertices {
+				if !slices.Contains(dists, v.Distance) {
+					dists = append(dists, v.Distance)
+				}
+
+				// The line runs along the X axis, so the left side is
+				// toward positive Y.
+				if v.Side != v.Point.Y {
+					t.Errorf("vertex %#v has wrong side; want %g", v, v.Point.Y)
+				}
+				if v.Distance != v.Point.X {
+					t.Errorf("vertex %#v has wrong distance; want %g", v, v.Point.X)
+				}
+			}
+			slices.Sort(dists)
+			if !slices.Equal(dists, test.WantDists) {
+				t.Errorf("wrong distances %#v; want %#v", dists, test.WantDists)
+			}
+		})
+	}
+
+	// Round caps' vertices have distances that follow their position.
+	got := Stroke(path, geom.StrokeStyle{Width: 2, Cap: geom.RoundCap}, 0.01)
+	for _, v := range got.Vertices {
+		if math.Abs(v.Distance-v.Point.X) > 1e-9 || math.Abs(v.Side-v.Point.Y) > 1e-9 {
+			t.Errorf("round cap vertex %#v has wrong attributes", v)
+		}
+	}
+}